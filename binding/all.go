@@ -0,0 +1,139 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Source 组合绑定的数据来源
+type Source string
+
+const (
+	SourceURI    Source = "uri"    // 路由参数，使用 uri 标签
+	SourceHeader Source = "header" // 请求头，使用 header 标签
+	SourceQuery  Source = "query"  // 查询参数，使用 form 标签
+	SourceBody   Source = "body"   // 请求体，表单请求体使用 form 标签，其他请求体使用对应格式的标签（json、xml 等）
+)
+
+// Precedence 组合绑定的默认优先级，排在前面的数据来源优先，默认路由参数优先，请求体最后
+var Precedence = []Source{SourceURI, SourceHeader, SourceQuery, SourceBody}
+
+// taggedSource 使用指定标签从数据来源中取值
+type taggedSource struct {
+	tag    string
+	setter setter
+}
+
+// multiSource 按优先级依次从多个数据来源中取值，只绑定带有对应标签的字段，
+// 所有数据来源都没有值时使用标签中的 default 值，但不会覆盖已经绑定的值
+type multiSource []taggedSource
+
+var _ setter = multiSource(nil)
+
+func (ms multiSource) TrySet(value reflect.Value, field reflect.StructField, _ string, planOpt setOptions) (isSet bool, err error) {
+	var defaultOpt setOptions
+	for _, s := range ms {
+		tagValue, opts := head(field.Tag.Get(s.tag), ",")
+		if tagValue == "" || tagValue == "-" {
+			continue
+		}
+		opt := parseSetOptions(opts)
+		if opt.isDefaultExists && !defaultOpt.isDefaultExists {
+			defaultOpt = opt
+			defaultOpt.set = planOpt.set
+		}
+		// default 值在所有数据来源都没有值时才使用，其他选项（例如 parser）传给数据来源
+		srcOpt := setOptions{parser: opt.parser, set: planOpt.set}
+		if isSet, err = s.setter.TrySet(value, field, tagValue, srcOpt); isSet || err != nil {
+			return isSet, err
+		}
+	}
+	if defaultOpt.isDefaultExists && value.IsZero() {
+		return setByForm(value, field, nil, "", defaultOpt)
+	}
+	return false, nil
+}
+
+// BindAll 按 precedence 指定的优先级从路由参数、请求头、查询参数和请求体中绑定数据到 obj，
+// 全部数据绑定完成后统一执行一次参数校验，precedence 为空时使用 Precedence
+func BindAll(req *http.Request, uri map[string][]string, obj any, precedence ...Source) error {
+	return (*Config)(nil).BindAll(req, uri, obj, precedence...)
+}
+
+// BindAll 使用绑定配置进行组合绑定，precedence 为空时使用绑定配置中的 Precedence
+func (cfg *Config) BindAll(req *http.Request, uri map[string][]string, obj any, precedence ...Source) error {
+	if req == nil {
+		return errors.New("无效请求")
+	}
+	if len(precedence) == 0 {
+		precedence = cfg.precedence()
+	}
+
+	var sources multiSource
+	var decoder bodyDecoder
+	// 查询参数和表单请求体共享切片元素数量的限制
+	sliceBudget := MaxFormSliceElements
+	var custom Binding
+	// 请求体之前的数据来源数量，优先级高于请求体的数据来源需要在请求体解码后再绑定
+	bodyAt := -1
+	for _, s := range precedence {
+		switch s {
+		case SourceURI:
+			sources = append(sources, taggedSource{tag: "uri", setter: formSource(uri)})
+		case SourceHeader:
+			sources = append(sources, taggedSource{tag: "header", setter: headerSource(req.Header)})
+		case SourceQuery:
+			sources = append(sources, taggedSource{tag: "form", setter: newNestedFormSource(req.URL.Query(), "form", &sliceBudget)})
+		case SourceBody:
+			if req.Method == http.MethodGet || req.Body == nil || req.Body == http.NoBody {
+				continue
+			}
+			b := Default(req.Method, contentType(req))
+			if d, ok := b.(bodyDecoder); ok {
+				decoder = d
+				bodyAt = len(sources)
+				continue
+			}
+			if b != Form {
+				// 自定义绑定器无法只解码不校验，在其他数据来源绑定完成后最后绑定请求体
+				custom = b
+				continue
+			}
+			if err := req.ParseMultipartForm(defaultMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+				return err
+			}
+			sources = append(sources, taggedSource{tag: "form", setter: newNestedFormSource(req.PostForm, "form", &sliceBudget)})
+		default:
+			return errors.New("未知的数据来源: " + string(s))
+		}
+	}
+
+	if decoder == nil {
+		if err := mappingByPtr(obj, sources, ""); err != nil {
+			return err
+		}
+		if custom != nil {
+			return cfg.Bind(req, obj, custom)
+		}
+		return cfg.validate(obj)
+	}
+
+	if err := mappingByPtr(obj, sources[bodyAt:], ""); err != nil {
+		return err
+	}
+	if err := decoder.decode(req.Body, obj, cfg); err != nil {
+		return err
+	}
+	if err := mappingByPtr(obj, sources[:bodyAt], ""); err != nil {
+		return err
+	}
+	return cfg.validate(obj)
+}
+
+// 获取请求的 Content-Type，去掉 charset 等参数
+func contentType(req *http.Request) string {
+	ct, _, _ := strings.Cut(req.Header.Get("Content-Type"), ";")
+	return strings.TrimSpace(ct)
+}
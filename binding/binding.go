@@ -1,6 +1,9 @@
 package binding
 
-import "net/http"
+import (
+	"io"
+	"net/http"
+)
 
 const (
 	MIMEJSON              = "application/json"
@@ -30,6 +33,17 @@ type BindingBody interface {
 	BindBody([]byte, any) error
 }
 
+// bodyDecoder 只解码请求体而不执行参数校验，用于组合绑定等需要在全部数据绑定完成后再统一校验的场景
+type bodyDecoder interface {
+	decode(io.Reader, any, *Config) error
+}
+
+// BindingUri 从路由参数中读取参数数据
+type BindingUri interface {
+	Name() string
+	BindUri(map[string][]string, any) error
+}
+
 // StructValidator 验证结构体的有效性
 type StructValidator interface {
 	ValidateStruct(any) error
@@ -40,49 +54,37 @@ type StructValidator interface {
 var Validator StructValidator = &defaultValidator{}
 
 var (
-	JSON BindingBody = jsonBinding{}
-	//XML           BindingBody = xmlBinding{}
-	Form Binding = formBinding{}
-	//Query         Binding     = queryBinding{}
+	JSON  BindingBody = jsonBinding{}
+	XML   BindingBody = xmlBinding{}
+	Form  Binding     = formBinding{}
+	Query Binding     = queryBinding{}
 	//FormPost      Binding     = formPostBinding{}
 	//FormMultipart Binding     = formMultipartBinding{}
-	//ProtoBuf      BindingBody = protobufBinding{}
-	//MsgPack       BindingBody = msgpackBinding{}
-	//YAML          BindingBody = yamlBinding{}
-	//Uri           BindingUri  = uriBinding{}
-	//Header        Binding     = headerBinding{}
+	ProtoBuf BindingBody = protobufBinding{}
+	MsgPack  BindingBody = msgpackBinding{}
+	YAML     BindingBody = yamlBinding{}
+	Uri      BindingUri  = uriBinding{}
+	Header   Binding     = headerBinding{}
 	//Plain         BindingBody = plainBinding{}
-	//TOML          BindingBody = tomlBinding{}
+	TOML BindingBody = tomlBinding{}
 )
 
+// Default 根据请求方法和 Content-Type 从注册的绑定器中选择合适的绑定器，GET 请求和没有 Content-Type 的请求使用表单绑定，
+// 没有对应绑定器的 Content-Type 返回的绑定器在绑定时会返回 ErrUnsupportedMediaType
 func Default(method, contentType string) Binding {
-	if method == http.MethodGet {
+	if method == http.MethodGet || contentType == "" {
 		return Form
 	}
-
-	switch contentType {
-	case MIMEJSON:
-		return JSON
-	//case MIMEXML, MIMEXML2:
-	//	return XML
-	//case MIMEPROTOBUF:
-	//	return ProtoBuf
-	//case MIMEMSGPACK, MIMEMSGPACK2:
-	//	return MsgPack
-	//case MIMEYAML, MIMEYAML2:
-	//	return YAML
-	//case MIMETOML:
-	//	return TOML
-	//case MIMEMultipartPOSTForm:
-	//	return FormMultipart
-	default: // case MIMEPOSTForm:
-		return Form
+	if b, ok := Lookup(contentType); ok {
+		return b
 	}
+	return unsupportedBinding{contentType: contentType}
 }
 
-func validate(obj any) error {
-	if Validator == nil {
-		return nil
+// 解码请求体并执行参数校验
+func decodeAndValidate(d bodyDecoder, r io.Reader, obj any, cfg *Config) error {
+	if err := d.decode(r, obj, cfg); err != nil {
+		return err
 	}
-	return Validator.ValidateStruct(obj)
+	return cfg.validate(obj)
 }
@@ -0,0 +1,141 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+)
+
+// Config 绑定配置，Engine 可以持有自己的绑定配置，使同一进程中的多个 Engine 使用不同的参数校验和解码设置
+//
+// 空配置（nil）和字段的零值都表示使用对应的包级变量（Validator、EnableDecoderUseNumber 等）作为默认值，
+// 所以 &binding.Config{DecoderUseNumber: true} 仍然执行参数校验并限制 XML 的嵌套深度；
+// 需要关闭参数校验时使用 NoValidator，需要取消 XML 嵌套深度的限制时将 XMLMaxDepth 设置为负数，
+// 布尔类型的字段只能开启对应的功能，为 false 时使用包级变量的值
+type Config struct {
+	Validator                    StructValidator // 参数验证器，为空时使用 Validator，NoValidator 表示不执行参数校验
+	DecoderUseNumber             bool            // json 解码时是否将数字解码为 json.Number，为 false 时使用 EnableDecoderUseNumber
+	DecoderDisallowUnknownFields bool            // json 解码时是否不允许出现未知字段，为 false 时使用 EnableDecoderDisallowUnknownFields
+	XMLMaxDepth                  int             // XML 请求体允许的最大嵌套深度，为 0 时使用 XMLMaxDepth，小于 0 时不限制
+	XMLAllowDTD                  bool            // XML 请求体中是否允许包含 DTD 等指令，为 false 时使用 XMLAllowDTD
+	Precedence                   []Source        // 组合绑定的默认优先级，为空时使用 Precedence
+}
+
+// NoValidator 不执行参数校验的参数验证器，用于在绑定配置中关闭参数校验
+var NoValidator StructValidator = noValidator{}
+
+type noValidator struct{}
+
+func (noValidator) ValidateStruct(any) error { return nil }
+
+func (noValidator) Engine() any { return nil }
+
+// configBinding 支持绑定配置的绑定器
+type configBinding interface {
+	bind(*http.Request, any, *Config) error
+}
+
+// uriConfigBinding 支持绑定配置的路由参数绑定器
+type uriConfigBinding interface {
+	bindUri(map[string][]string, any, *Config) error
+}
+
+// NewConfig 使用包级变量的当前值实例化绑定配置
+func NewConfig() *Config {
+	return &Config{
+		Validator:                    Validator,
+		DecoderUseNumber:             EnableDecoderUseNumber,
+		DecoderDisallowUnknownFields: EnableDecoderDisallowUnknownFields,
+		XMLMaxDepth:                  XMLMaxDepth,
+		XMLAllowDTD:                  XMLAllowDTD,
+		Precedence:                   append([]Source(nil), Precedence...),
+	}
+}
+
+// Bind 使用绑定配置将请求数据绑定到 obj，不支持绑定配置的自定义绑定器直接调用其 Bind 方法
+func (cfg *Config) Bind(req *http.Request, obj any, b Binding) error {
+	switch b := b.(type) {
+	case bodyDecoder:
+		if req == nil || req.Body == nil {
+			return errors.New("无效请求")
+		}
+		return decodeAndValidate(b, req.Body, obj, cfg)
+	case configBinding:
+		return b.bind(req, obj, cfg)
+	default:
+		return b.Bind(req, obj)
+	}
+}
+
+// BindUri 使用绑定配置将路由参数绑定到 obj，不支持绑定配置的自定义绑定器直接调用其 BindUri 方法
+func (cfg *Config) BindUri(m map[string][]string, obj any, b BindingUri) error {
+	if ub, ok := b.(uriConfigBinding); ok {
+		return ub.bindUri(m, obj, cfg)
+	}
+	return b.BindUri(m, obj)
+}
+
+// BindBody 使用绑定配置将请求体绑定到 obj，不支持绑定配置的自定义绑定器直接调用其 BindBody 方法
+func (cfg *Config) BindBody(body []byte, obj any, b BindingBody) error {
+	if d, ok := b.(bodyDecoder); ok {
+		return decodeAndValidate(d, bytes.NewReader(body), obj, cfg)
+	}
+	return b.BindBody(body, obj)
+}
+
+// Validate 使用绑定配置中的参数验证器校验 obj
+func (cfg *Config) Validate(obj any) error {
+	return cfg.validate(obj)
+}
+
+// StructValidator 获取参数验证器，没有设置参数验证器时返回 Validator
+func (cfg *Config) StructValidator() StructValidator {
+	if cfg == nil || cfg.Validator == nil {
+		return Validator
+	}
+	return cfg.Validator
+}
+
+func (cfg *Config) validate(obj any) error {
+	v := cfg.StructValidator()
+	if v == nil {
+		return nil
+	}
+	return v.ValidateStruct(obj)
+}
+
+func (cfg *Config) decoderUseNumber() bool {
+	if cfg == nil || !cfg.DecoderUseNumber {
+		return EnableDecoderUseNumber
+	}
+	return true
+}
+
+func (cfg *Config) decoderDisallowUnknownFields() bool {
+	if cfg == nil || !cfg.DecoderDisallowUnknownFields {
+		return EnableDecoderDisallowUnknownFields
+	}
+	return true
+}
+
+// 获取 XML 请求体允许的最大嵌套深度，返回值小于等于 0 时不限制
+func (cfg *Config) xmlMaxDepth() int {
+	if cfg == nil || cfg.XMLMaxDepth == 0 {
+		return XMLMaxDepth
+	}
+	return cfg.XMLMaxDepth
+}
+
+func (cfg *Config) xmlAllowDTD() bool {
+	if cfg == nil || !cfg.XMLAllowDTD {
+		return XMLAllowDTD
+	}
+	return true
+}
+
+func (cfg *Config) precedence() []Source {
+	if cfg == nil || len(cfg.Precedence) == 0 {
+		return Precedence
+	}
+	return cfg.Precedence
+}
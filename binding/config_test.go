@@ -0,0 +1,43 @@
+package binding
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type configUser struct {
+	Name string `json:"name" xml:"name" binding:"required"`
+}
+
+func TestConfigZeroValueUsesDefaults(t *testing.T) {
+	cfg := &Config{DecoderUseNumber: true}
+	if cfg.StructValidator() != Validator {
+		t.Fatal("没有设置参数验证器时应该使用 Validator")
+	}
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+	if err := cfg.Bind(req, &configUser{}, JSON); err == nil {
+		t.Fatal("零值配置不应该关闭参数校验")
+	}
+	if got := cfg.xmlMaxDepth(); got != XMLMaxDepth {
+		t.Fatalf("got max depth %d", got)
+	}
+
+	deep := strings.Repeat("<a>", XMLMaxDepth+1) + strings.Repeat("</a>", XMLMaxDepth+1)
+	if err := cfg.BindBody([]byte(deep), &configUser{}, XML); err == nil {
+		t.Fatal("零值配置不应该取消 XML 嵌套深度的限制")
+	}
+}
+
+func TestConfigExplicitOptOut(t *testing.T) {
+	cfg := &Config{Validator: NoValidator, XMLMaxDepth: -1}
+	req := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(`{}`)))
+	if err := cfg.Bind(req, &configUser{}, JSON); err != nil {
+		t.Fatal(err)
+	}
+	deep := "<configUser>" + strings.Repeat("<a>", XMLMaxDepth+1) + strings.Repeat("</a>", XMLMaxDepth+1) + "</configUser>"
+	if err := cfg.BindBody([]byte(deep), &configUser{}, XML); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -31,7 +31,11 @@ func (err SliceValidationError) Error() string {
 		}
 	}
 	return b.String()
+}
 
+// Unwrap 返回全部元素的校验错误
+func (err SliceValidationError) Unwrap() []error {
+	return err
 }
 
 // 单例模式实例化参数验证器引擎（tag 默认为 binding）
@@ -39,6 +43,8 @@ func (v *defaultValidator) lazyinit() {
 	v.once.Do(func() {
 		v.validate = validator.New()
 		v.validate.SetTagName("binding")
+		// 校验错误中的字段名称使用 json/form 标签名称
+		v.validate.RegisterTagNameFunc(fieldTagName)
 	})
 }
 
@@ -57,14 +63,17 @@ func (v *defaultValidator) ValidateStruct(obj any) error {
 	case reflect.Struct:
 		return v.validateStruct(obj)
 	case reflect.Slice, reflect.Array:
+		// 校验错误与切片元素的下标一一对应，校验通过的元素对应 nil
 		count := value.Len()
-		validateRet := make(SliceValidationError, 0)
+		validateRet := make(SliceValidationError, count)
+		failed := false
 		for i := 0; i < count; i++ {
 			if err := v.ValidateStruct(value.Index(i).Interface()); err != nil {
-				validateRet = append(validateRet, err)
+				validateRet[i] = err
+				failed = true
 			}
 		}
-		if len(validateRet) == 0 {
+		if !failed {
 			return nil
 		}
 		return validateRet
@@ -74,7 +83,41 @@ func (v *defaultValidator) ValidateStruct(obj any) error {
 }
 func (v *defaultValidator) validateStruct(obj any) error {
 	v.lazyinit()
-	return v.validate.Struct(obj)
+	err := v.validate.Struct(obj)
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+	// 记录校验对象的根类型名称，翻译时据此去掉命名空间开头的结构体名称
+	root := reflect.TypeOf(obj)
+	if root.Kind() == reflect.Ptr {
+		root = root.Elem()
+	}
+	for i, fe := range errs {
+		errs[i] = rootedFieldError{FieldError: fe, root: root.Name()}
+	}
+	return errs
+}
+
+// rootedFieldError 带有根类型名称的字段校验错误，匿名结构体的根类型名称为空，
+// validator 的命名空间以根类型名称开头，匿名结构体的命名空间直接以字段名称开头
+type rootedFieldError struct {
+	validator.FieldError
+	root string
+}
+
+// 获取字段的 json 标签名称，没有 json 标签时使用 form 标签名称，都没有时使用字段名称
+func fieldTagName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
 }
 
 // Engine 参数验证器引擎
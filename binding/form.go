@@ -15,7 +15,11 @@ func (formBinding) Name() string {
 	return "form"
 }
 
-func (formBinding) Bind(req *http.Request, obj any) error {
+func (b formBinding) Bind(req *http.Request, obj any) error {
+	return b.bind(req, obj, nil)
+}
+
+func (formBinding) bind(req *http.Request, obj any, cfg *Config) error {
 	if err := req.ParseForm(); err != nil {
 		return err
 	}
@@ -28,14 +32,18 @@ func (formBinding) Bind(req *http.Request, obj any) error {
 		return err
 	}
 
-	return validate(obj)
+	return cfg.validate(obj)
 }
 
 func (formPostBinding) Name() string {
 	return "form-urlencoded"
 }
 
-func (formPostBinding) Bind(req *http.Request, obj any) error {
+func (b formPostBinding) Bind(req *http.Request, obj any) error {
+	return b.bind(req, obj, nil)
+}
+
+func (formPostBinding) bind(req *http.Request, obj any, cfg *Config) error {
 	if err := req.ParseForm(); err != nil {
 		return err
 	}
@@ -43,7 +51,7 @@ func (formPostBinding) Bind(req *http.Request, obj any) error {
 		return err
 	}
 
-	return validate(obj)
+	return cfg.validate(obj)
 }
 
 func (formMultipartBinding) Name() string {
@@ -1,12 +1,15 @@
 package binding
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
+	"maps"
 	"mime/multipart"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/zhangweijie11/zGin/internal/bytesconv"
@@ -21,8 +24,18 @@ var (
 
 	// ErrConvertToMapString can not convert to map[string]string
 	ErrConvertToMapString = errors.New("can not convert to map of strings")
+
+	// ErrFormSliceIndex 表单嵌套 key 中的切片下标无效、超过 MaxFormSliceIndex，或一次绑定分配的切片元素总数超过 MaxFormSliceElements
+	ErrFormSliceIndex = errors.New("表单切片下标无效或超过限制")
 )
 
+// MaxFormSliceIndex 表单嵌套 key（例如 items[0][sku]）中允许的最大切片下标，防止通过超大下标分配大量内存
+var MaxFormSliceIndex = 1000
+
+// MaxFormSliceElements 一次绑定中所有嵌套切片（包括切片元素中的切片）允许分配的元素总数，
+// 嵌套的切片下标会使分配的元素数量成倍增加，只限制单个下标无法阻止很小的请求分配大量内存
+var MaxFormSliceElements = 10000
+
 func mapURI(ptr any, m map[string][]string) error {
 	return mapFormByTag(ptr, m, "uri")
 }
@@ -35,8 +48,6 @@ func MapFormWithTag(ptr any, form map[string][]string, tag string) error {
 	return mapFormByTag(ptr, form, tag)
 }
 
-var emptyField = reflect.StructField{}
-
 func mapFormByTag(ptr any, form map[string][]string, tag string) error {
 	ptrVal := reflect.ValueOf(ptr)
 	var pointed any
@@ -52,7 +63,7 @@ func mapFormByTag(ptr any, form map[string][]string, tag string) error {
 		return setFormMap(ptr, form)
 	}
 
-	return mappingByPtr(ptr, formSource(form), tag)
+	return mappingByPtr(ptr, newNestedFormSource(form, tag, nil), tag)
 }
 
 // setter 尝试在遍历结构体的字段时设置 value
@@ -69,13 +80,220 @@ func (form formSource) TrySet(value reflect.Value, field reflect.StructField, ta
 	return setByForm(value, field, form, tagValue, opt)
 }
 
+// nestedValue 嵌套 key 剩余的路径及其对应的值
+type nestedValue struct {
+	path   []string
+	values []string
+}
+
+// nestedFormSource 支持嵌套 key 的表单数据来源，user[address][city] 和 user.address.city 可以绑定到嵌套结构体，
+// items[0][sku] 可以绑定到结构体切片，attrs[color] 可以绑定到 map[string]T，tags[] 与 tags 相同
+type nestedFormSource struct {
+	form   map[string][]string
+	nested map[string][]nestedValue // 以嵌套 key 的第一段分组
+	tag    string                   // 绑定嵌套结构体时使用的标签
+	budget *int                     // 本次绑定剩余可以分配的切片元素数量，嵌套结构体的数据来源共享同一个计数
+}
+
+var _ setter = (*nestedFormSource)(nil)
+
+// newNestedFormSource 实例化嵌套表单数据来源，budget 为本次绑定剩余可以分配的切片元素数量，
+// 同一次绑定的多个数据来源共享 budget，为空时使用 MaxFormSliceElements
+func newNestedFormSource(form map[string][]string, tag string, budget *int) *nestedFormSource {
+	if budget == nil {
+		budget = new(int)
+		*budget = MaxFormSliceElements
+	}
+	s := &nestedFormSource{form: form, tag: tag, budget: budget}
+	cloned := false
+	for key, values := range form {
+		path, ok := parseFormKey(key)
+		if !ok {
+			continue
+		}
+		// tags[] 需要追加到表单数据中，先复制一份避免修改请求中的表单数据
+		if len(path) == 1 && !cloned {
+			s.form = maps.Clone(form)
+			cloned = true
+		}
+		s.add(path, values)
+	}
+	return s
+}
+
+// 添加嵌套数据，只有一段的路径（例如 tags[]）作为普通 key 追加到表单数据中
+func (s *nestedFormSource) add(path []string, values []string) {
+	if len(path) == 1 {
+		if s.form == nil {
+			s.form = make(map[string][]string)
+		}
+		s.form[path[0]] = append(s.form[path[0]], values...)
+		return
+	}
+	if s.nested == nil {
+		s.nested = make(map[string][]nestedValue)
+	}
+	s.nested[path[0]] = append(s.nested[path[0]], nestedValue{path: path[1:], values: values})
+}
+
+// TrySet 存在完全匹配的 key 时按普通表单绑定，否则尝试使用嵌套 key 绑定
+func (s *nestedFormSource) TrySet(value reflect.Value, field reflect.StructField, tagValue string, opt setOptions) (isSet bool, err error) {
+	entries, ok := s.nested[tagValue]
+	if _, exists := s.form[tagValue]; exists || !ok {
+		return setByForm(value, field, s.form, tagValue, opt)
+	}
+	return s.setNested(value, field, entries)
+}
+
+// 根据嵌套数据设置结构体、切片、数组或 map 类型的字段
+func (s *nestedFormSource) setNested(value reflect.Value, field reflect.StructField, entries []nestedValue) (bool, error) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		sub := &nestedFormSource{tag: s.tag, budget: s.budget}
+		for _, e := range entries {
+			sub.add(e.path, e.values)
+		}
+		return mapping(value, emptyFieldPlan, sub, s.tag)
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return false, nil
+		}
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		for key, group := range groupNested(entries) {
+			elem := reflect.New(value.Type().Elem()).Elem()
+			if err := s.setElem(elem, field, group); err != nil {
+				return false, err
+			}
+			value.SetMapIndex(reflect.ValueOf(key).Convert(value.Type().Key()), elem)
+		}
+		return true, nil
+	case reflect.Slice, reflect.Array:
+		groups := make(map[int][]nestedValue)
+		maxIndex := -1
+		for key, group := range groupNested(entries) {
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index > MaxFormSliceIndex {
+				return false, fmt.Errorf("%w: %s[%s]", ErrFormSliceIndex, field.Name, key)
+			}
+			if value.Kind() == reflect.Array && index >= value.Len() {
+				return false, fmt.Errorf("%w: %s[%s]", ErrFormSliceIndex, field.Name, key)
+			}
+			groups[index] = group
+			maxIndex = max(maxIndex, index)
+		}
+		if value.Kind() == reflect.Slice && maxIndex >= value.Len() {
+			if *s.budget -= maxIndex + 1; *s.budget < 0 {
+				return false, fmt.Errorf("%w: %s 分配的切片元素总数超过 %d", ErrFormSliceIndex, field.Name, MaxFormSliceElements)
+			}
+			slice := reflect.MakeSlice(value.Type(), maxIndex+1, maxIndex+1)
+			reflect.Copy(slice, value)
+			value.Set(slice)
+		}
+		for index, group := range groups {
+			if err := s.setElem(value.Index(index), field, group); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// 设置切片、数组或 map 的元素，路径已经结束时直接设置值，否则继续绑定嵌套数据
+func (s *nestedFormSource) setElem(elem reflect.Value, field reflect.StructField, entries []nestedValue) error {
+	var values []string
+	var nested []nestedValue
+	for _, e := range entries {
+		if len(e.path) == 0 {
+			values = append(values, e.values...)
+		} else {
+			nested = append(nested, e)
+		}
+	}
+	if len(nested) > 0 {
+		_, err := s.setNested(elem, field, nested)
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	if ok, err := trySetCustom(values[0], elem); ok {
+		return err
+	}
+	if elem.Kind() == reflect.Slice {
+		return setSlice(values, elem, field)
+	}
+	return setWithProperType(values[0], elem, field)
+}
+
+// 按路径的第一段分组，分组后的路径去掉第一段
+func groupNested(entries []nestedValue) map[string][]nestedValue {
+	groups := make(map[string][]nestedValue)
+	for _, e := range entries {
+		groups[e.path[0]] = append(groups[e.path[0]], nestedValue{path: e.path[1:], values: e.values})
+	}
+	return groups
+}
+
+// 解析嵌套 key，user[address][city] 和 user.address.city 都解析为 [user address city]，
+// 末尾的 [] 会被忽略，不是嵌套 key 或格式错误时返回 false
+func parseFormKey(key string) ([]string, bool) {
+	i := strings.IndexAny(key, "[.")
+	if i <= 0 {
+		return nil, false
+	}
+	path := []string{key[:i]}
+	rest := key[i:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '[':
+			j := strings.IndexByte(rest, ']')
+			if j < 0 {
+				return nil, false
+			}
+			if j == 1 && len(rest) > 2 { // [] 只能出现在末尾
+				return nil, false
+			}
+			if j > 1 {
+				path = append(path, rest[1:j])
+			}
+			rest = rest[j+1:]
+		case '.':
+			rest = rest[1:]
+			j := strings.IndexAny(rest, "[.")
+			if j < 0 {
+				j = len(rest)
+			}
+			if j == 0 {
+				return nil, false
+			}
+			path = append(path, rest[:j])
+			rest = rest[j:]
+		default:
+			return nil, false
+		}
+	}
+	return path, true
+}
+
 func mappingByPtr(ptr any, setter setter, tag string) error {
-	_, err := mapping(reflect.ValueOf(ptr), emptyField, setter, tag)
+	_, err := mapping(reflect.ValueOf(ptr), emptyFieldPlan, setter, tag)
 	return err
 }
 
-func mapping(value reflect.Value, field reflect.StructField, setter setter, tag string) (bool, error) {
-	if field.Tag.Get(tag) == "-" { // just ignoring this field
+// mapping 使用缓存的绑定计划遍历结构体字段并设置值
+func mapping(value reflect.Value, fp *fieldPlan, setter setter, tag string) (bool, error) {
+	if fp.ignored { // just ignoring this field
 		return false, nil
 	}
 
@@ -88,7 +306,7 @@ func mapping(value reflect.Value, field reflect.StructField, setter setter, tag
 			isNew = true
 			vPtr = reflect.New(value.Type().Elem())
 		}
-		isSet, err := mapping(vPtr.Elem(), field, setter, tag)
+		isSet, err := mapping(vPtr.Elem(), fp, setter, tag)
 		if err != nil {
 			return false, err
 		}
@@ -98,8 +316,9 @@ func mapping(value reflect.Value, field reflect.StructField, setter setter, tag
 		return isSet, nil
 	}
 
-	if vKind != reflect.Struct || !field.Anonymous {
-		ok, err := tryToSetValue(value, field, setter, tag)
+	// 根结构体没有名称，不能直接设置值
+	if (vKind != reflect.Struct || !fp.field.Anonymous) && fp.name != "" {
+		ok, err := setter.TrySet(value, fp.field, fp.name, fp.opt)
 		if err != nil {
 			return false, err
 		}
@@ -109,15 +328,11 @@ func mapping(value reflect.Value, field reflect.StructField, setter setter, tag
 	}
 
 	if vKind == reflect.Struct {
-		tValue := value.Type()
+		plan := loadStructPlan(value.Type(), tag)
 
 		var isSet bool
-		for i := 0; i < value.NumField(); i++ {
-			sf := tValue.Field(i)
-			if sf.PkgPath != "" && !sf.Anonymous { // unexported
-				continue
-			}
-			ok, err := mapping(value.Field(i), sf, setter, tag)
+		for i := range plan {
+			ok, err := mapping(value.Field(plan[i].index), &plan[i], setter, tag)
 			if err != nil {
 				return false, err
 			}
@@ -131,33 +346,28 @@ func mapping(value reflect.Value, field reflect.StructField, setter setter, tag
 type setOptions struct {
 	isDefaultExists bool
 	defaultValue    string
+	// 标签中 parser 选项指定的解析器名称，例如 parser=json
+	parser string
+	// 绑定计划中根据字段类型预先选择的设置值函数，为空时使用 setWithProperType
+	set func(val string, value reflect.Value) error
 }
 
-func tryToSetValue(value reflect.Value, field reflect.StructField, setter setter, tag string) (bool, error) {
-	var tagValue string
+// 解析标签中的选项，例如 default=10
+func parseSetOptions(opts string) setOptions {
 	var setOpt setOptions
-
-	tagValue = field.Tag.Get(tag)
-	tagValue, opts := head(tagValue, ",")
-
-	if tagValue == "" { // default value is FieldName
-		tagValue = field.Name
-	}
-	if tagValue == "" { // when field is "emptyField" variable
-		return false, nil
-	}
-
 	var opt string
 	for len(opts) > 0 {
 		opt, opts = head(opts, ",")
 
-		if k, v := head(opt, "="); k == "default" {
+		switch k, v := head(opt, "="); k {
+		case "default":
 			setOpt.isDefaultExists = true
 			setOpt.defaultValue = v
+		case "parser":
+			setOpt.parser = v
 		}
 	}
-
-	return setter.TrySet(value, field, tagValue, setOpt)
+	return setOpt
 }
 
 // BindUnmarshaler 用于包装 UnmarshalParam 方法的接口
@@ -176,6 +386,74 @@ func trySetCustom(val string, value reflect.Value) (isSet bool, err error) {
 	return false, nil
 }
 
+// trySetTextUnmarshaler 字段实现了 encoding.TextUnmarshaler 时使用 UnmarshalText 设置值，空字符串设置为零值
+func trySetTextUnmarshaler(val string, value reflect.Value) (isSet bool, err error) {
+	if !value.CanAddr() {
+		return false, nil
+	}
+	v, ok := value.Addr().Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return false, nil
+	}
+	if val == "" {
+		value.Set(reflect.Zero(value.Type()))
+		return true, nil
+	}
+	return true, v.UnmarshalText(bytesconv.StringToBytes(val))
+}
+
+// 判断类型是否实现了 BindUnmarshaler 或 encoding.TextUnmarshaler，这些类型需要使用自定义的解析方法
+func isCustomUnmarshaler(typ reflect.Type) bool {
+	ptr := reflect.PointerTo(typ)
+	return ptr.Implements(bindUnmarshalerType) || ptr.Implements(textUnmarshalerType)
+}
+
+var (
+	bindUnmarshalerType = reflect.TypeOf((*BindUnmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// FormParser 使用指定编码解析表单字段的值，通过 form:"name,parser=json" 标签选择
+type FormParser func(val string, ptr any) error
+
+var (
+	formParsersMu sync.RWMutex
+	formParsers   = map[string]FormParser{
+		"json": func(val string, ptr any) error {
+			return json.Unmarshal(bytesconv.StringToBytes(val), ptr)
+		},
+		"text": func(val string, ptr any) error {
+			u, ok := ptr.(encoding.TextUnmarshaler)
+			if !ok {
+				return fmt.Errorf("%T 没有实现 encoding.TextUnmarshaler", ptr)
+			}
+			return u.UnmarshalText(bytesconv.StringToBytes(val))
+		},
+	}
+)
+
+// RegisterFormParser 注册表单字段的解析器，相同名称的解析器会被覆盖
+func RegisterFormParser(name string, parser FormParser) {
+	formParsersMu.Lock()
+	defer formParsersMu.Unlock()
+	formParsers[name] = parser
+}
+
+// 使用标签中 parser 选项指定的解析器设置值，空字符串设置为零值
+func setWithParser(name, val string, value reflect.Value) error {
+	formParsersMu.RLock()
+	parser, ok := formParsers[name]
+	formParsersMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("未知的表单解析器 %q", name)
+	}
+	if val == "" {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+	return parser(val, value.Addr().Interface())
+}
+
 func trySplit(vs []string, field reflect.StructField) (newVs []string, err error) {
 	cfTag := field.Tag.Get("collection_format")
 	if cfTag == "" || cfTag == "multi" {
@@ -214,6 +492,14 @@ func setByForm(value reflect.Value, field reflect.StructField, form map[string][
 		return false, nil
 	}
 
+	if opt.parser != "" {
+		val := opt.defaultValue
+		if len(vs) > 0 {
+			val = vs[0]
+		}
+		return true, setWithParser(opt.parser, val, value)
+	}
+
 	switch value.Kind() {
 	case reflect.Slice:
 		if !ok {
@@ -259,11 +545,26 @@ func setByForm(value reflect.Value, field reflect.StructField, form map[string][
 		if ok, err := trySetCustom(val, value); ok {
 			return ok, err
 		}
+		if opt.set != nil {
+			return true, opt.set(val, value)
+		}
 		return true, setWithProperType(val, value, field)
 	}
 }
 
 func setWithProperType(val string, value reflect.Value, field reflect.StructField) error {
+	if value.CanAddr() {
+		if ok, err := trySetCustom(val, value); ok {
+			return err
+		}
+		// time.Time 同样实现了 encoding.TextUnmarshaler，需要按照 time_format 标签解析
+		if _, isTime := value.Interface().(time.Time); !isTime {
+			if ok, err := trySetTextUnmarshaler(val, value); ok {
+				return err
+			}
+		}
+	}
+
 	switch value.Kind() {
 	case reflect.Int:
 		return setIntField(val, 0, value)
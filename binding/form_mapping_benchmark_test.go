@@ -0,0 +1,182 @@
+package binding
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type benchAddress struct {
+	City string `form:"city"`
+	Zip  string `form:"zip,default=000000"`
+}
+
+type benchQuery struct {
+	Page     int           `form:"page,default=1"`
+	PageSize int           `form:"page_size,default=20"`
+	Keyword  string        `form:"keyword"`
+	Sort     string        `form:"sort,default=created_at"`
+	Desc     bool          `form:"desc"`
+	Score    float64       `form:"score"`
+	Timeout  time.Duration `form:"timeout"`
+	Since    time.Time     `form:"since" time_format:"2006-01-02" time_utc:"1"`
+	Tags     []string      `form:"tags"`
+	Owner    *string       `form:"owner"`
+	Ignored  string        `form:"-"`
+	Address  benchAddress
+}
+
+type benchHeader struct {
+	RequestID string `header:"X-Request-Id"`
+	Tenant    string `header:"X-Tenant,default=public"`
+	Retry     int    `header:"X-Retry"`
+	Trace     bool   `header:"X-Trace"`
+}
+
+var benchForm = formSource{
+	"page":      {"3"},
+	"keyword":   {"gin"},
+	"desc":      {"true"},
+	"score":     {"9.5"},
+	"timeout":   {"3s"},
+	"since":     {"2024-01-02"},
+	"tags":      {"a", "b", "c"},
+	"owner":     {"root"},
+	"city":      {"beijing"},
+	"page_size": {"50"},
+}
+
+var benchHeaders = headerSource(http.Header{
+	"X-Request-Id": {"abc"},
+	"X-Retry":      {"2"},
+	"X-Trace":      {"true"},
+})
+
+// uncachedMappingByPtr 引入绑定计划之前的实现，每次绑定都遍历字段并重新解析标签，用于对比绑定计划的性能
+func uncachedMappingByPtr(ptr any, setter setter, tag string) error {
+	_, err := uncachedMapping(reflect.ValueOf(ptr), reflect.StructField{}, setter, tag)
+	return err
+}
+
+func uncachedMapping(value reflect.Value, field reflect.StructField, setter setter, tag string) (bool, error) {
+	if field.Tag.Get(tag) == "-" {
+		return false, nil
+	}
+
+	vKind := value.Kind()
+
+	if vKind == reflect.Ptr {
+		var isNew bool
+		vPtr := value
+		if value.IsNil() {
+			isNew = true
+			vPtr = reflect.New(value.Type().Elem())
+		}
+		isSet, err := uncachedMapping(vPtr.Elem(), field, setter, tag)
+		if err != nil {
+			return false, err
+		}
+		if isNew && isSet {
+			value.Set(vPtr)
+		}
+		return isSet, nil
+	}
+
+	if vKind != reflect.Struct || !field.Anonymous {
+		tagValue, opts := head(field.Tag.Get(tag), ",")
+		if tagValue == "" {
+			tagValue = field.Name
+		}
+		if tagValue != "" {
+			ok, err := setter.TrySet(value, field, tagValue, parseSetOptions(opts))
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+	}
+
+	if vKind == reflect.Struct {
+		tValue := value.Type()
+
+		var isSet bool
+		for i := 0; i < value.NumField(); i++ {
+			sf := tValue.Field(i)
+			if sf.PkgPath != "" && !sf.Anonymous {
+				continue
+			}
+			ok, err := uncachedMapping(value.Field(i), sf, setter, tag)
+			if err != nil {
+				return false, err
+			}
+			isSet = isSet || ok
+		}
+		return isSet, nil
+	}
+	return false, nil
+}
+
+// 两种实现的绑定结果必须一致，否则对比没有意义
+func checkSameResult[T any](b *testing.B, setter setter, tag string) {
+	var cached, uncached T
+	if err := mappingByPtr(&cached, setter, tag); err != nil {
+		b.Fatal(err)
+	}
+	if err := uncachedMappingByPtr(&uncached, setter, tag); err != nil {
+		b.Fatal(err)
+	}
+	if !reflect.DeepEqual(cached, uncached) {
+		b.Fatalf("绑定结果不一致:\n%+v\n%+v", cached, uncached)
+	}
+}
+
+func BenchmarkMappingFormCached(b *testing.B) {
+	checkSameResult[benchQuery](b, benchForm, "form")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var q benchQuery
+		if err := mappingByPtr(&q, benchForm, "form"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMappingFormUncached(b *testing.B) {
+	checkSameResult[benchQuery](b, benchForm, "form")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var q benchQuery
+		if err := uncachedMappingByPtr(&q, benchForm, "form"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMappingHeaderCached(b *testing.B) {
+	checkSameResult[benchHeader](b, benchHeaders, "header")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var h benchHeader
+		if err := mappingByPtr(&h, benchHeaders, "header"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMappingHeaderUncached(b *testing.B) {
+	checkSameResult[benchHeader](b, benchHeaders, "header")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var h benchHeader
+		if err := uncachedMappingByPtr(&h, benchHeaders, "header"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
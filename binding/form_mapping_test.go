@@ -0,0 +1,62 @@
+package binding
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+type formSliceItem struct {
+	Tags []struct {
+		Name string `form:"name"`
+	} `form:"tags"`
+}
+
+type formSliceOrder struct {
+	Items []formSliceItem `form:"items"`
+}
+
+func TestMapFormNestedSlices(t *testing.T) {
+	form := map[string][]string{
+		"items[0][tags][1][name]": {"b"},
+		"items[1][tags][0][name]": {"c"},
+	}
+	var order formSliceOrder
+	if err := MapFormWithTag(&order, form, "form"); err != nil {
+		t.Fatal(err)
+	}
+	if len(order.Items) != 2 || len(order.Items[0].Tags) != 2 || order.Items[0].Tags[1].Name != "b" || order.Items[1].Tags[0].Name != "c" {
+		t.Fatalf("got %+v", order)
+	}
+}
+
+func TestMapFormSliceElementsLimit(t *testing.T) {
+	// 每个 key 只有几十个字节，但嵌套的下标会使分配的元素数量成倍增加
+	form := make(map[string][]string, MaxFormSliceIndex+1)
+	for i := 0; i <= MaxFormSliceIndex; i++ {
+		form["items["+strconv.Itoa(i)+"][tags]["+strconv.Itoa(MaxFormSliceIndex)+"][name]"] = []string{"x"}
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	var order formSliceOrder
+	err := MapFormWithTag(&order, form, "form")
+	runtime.ReadMemStats(&after)
+
+	if !errors.Is(err, ErrFormSliceIndex) {
+		t.Fatalf("got error %v", err)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 8<<20 {
+		t.Fatalf("分配了 %d 字节内存", allocated)
+	}
+}
+
+func TestMapFormSliceIndexLimit(t *testing.T) {
+	form := map[string][]string{"items[" + strconv.Itoa(MaxFormSliceIndex+1) + "][tags][0][name]": {"x"}}
+	var order formSliceOrder
+	if err := MapFormWithTag(&order, form, "form"); !errors.Is(err, ErrFormSliceIndex) {
+		t.Fatalf("got error %v", err)
+	}
+}
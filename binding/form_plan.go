@@ -0,0 +1,161 @@
+package binding
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fieldPlan 结构体字段的绑定计划，缓存字段下标、解析后的标签和设置值的函数，避免每次请求都重新解析标签
+type fieldPlan struct {
+	index   int
+	field   reflect.StructField
+	name    string     // 标签名称，没有标签时为字段名称
+	ignored bool       // 标签为 - 时忽略该字段
+	opt     setOptions // 解析后的标签选项
+}
+
+// planKey 绑定计划的缓存 key，同一类型使用不同标签绑定时的计划不同
+type planKey struct {
+	typ reflect.Type
+	tag string
+}
+
+// 以 planKey 为 key 缓存结构体的绑定计划（[]fieldPlan）
+var planCache sync.Map
+
+// 根结构体的绑定计划，没有名称所以不会直接设置值
+var emptyFieldPlan = &fieldPlan{}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// 获取结构体类型的绑定计划，第一次使用时构建并缓存
+func loadStructPlan(typ reflect.Type, tag string) []fieldPlan {
+	key := planKey{typ: typ, tag: tag}
+	if plan, ok := planCache.Load(key); ok {
+		return plan.([]fieldPlan)
+	}
+	plan, _ := planCache.LoadOrStore(key, buildStructPlan(typ, tag))
+	return plan.([]fieldPlan)
+}
+
+// 构建结构体类型的绑定计划，跳过未导出的非匿名字段
+func buildStructPlan(typ reflect.Type, tag string) []fieldPlan {
+	plan := make([]fieldPlan, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous { // unexported
+			continue
+		}
+		tagValue, opts := head(sf.Tag.Get(tag), ",")
+		fp := fieldPlan{
+			index:   i,
+			field:   sf,
+			name:    tagValue,
+			ignored: tagValue == "-",
+			opt:     parseSetOptions(opts),
+		}
+		if fp.name == "" { // default value is FieldName
+			fp.name = sf.Name
+		}
+		fp.opt.set = newValueSetter(sf.Type, sf)
+		plan = append(plan, fp)
+	}
+	return plan
+}
+
+// 根据字段类型预先选择设置值的函数，返回 nil 时使用 setWithProperType
+func newValueSetter(typ reflect.Type, field reflect.StructField) func(val string, value reflect.Value) error {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ != timeType && isCustomUnmarshaler(typ) {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		bitSize := typ.Bits()
+		if typ.Kind() == reflect.Int {
+			bitSize = 0
+		}
+		return func(val string, value reflect.Value) error {
+			return setIntField(val, bitSize, value)
+		}
+	case reflect.Int64:
+		if typ == durationType {
+			return setTimeDuration
+		}
+		return func(val string, value reflect.Value) error {
+			return setIntField(val, 64, value)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bitSize := typ.Bits()
+		if typ.Kind() == reflect.Uint {
+			bitSize = 0
+		}
+		return func(val string, value reflect.Value) error {
+			return setUintField(val, bitSize, value)
+		}
+	case reflect.Bool:
+		return setBoolField
+	case reflect.Float32, reflect.Float64:
+		bitSize := typ.Bits()
+		return func(val string, value reflect.Value) error {
+			return setFloatField(val, bitSize, value)
+		}
+	case reflect.String:
+		return func(val string, value reflect.Value) error {
+			value.SetString(val)
+			return nil
+		}
+	case reflect.Struct:
+		if typ == timeType {
+			return newTimeSetter(field)
+		}
+	}
+	return nil
+}
+
+// 预先解析 time_format、time_utc 和 time_location 标签，返回 nil 时使用 setTimeField
+func newTimeSetter(field reflect.StructField) func(val string, value reflect.Value) error {
+	timeFormat := field.Tag.Get("time_format")
+	if timeFormat == "" {
+		timeFormat = time.RFC3339
+	}
+	switch strings.ToLower(timeFormat) {
+	case "unix", "unixnano":
+		return nil
+	}
+
+	l := time.Local
+	if isUTC, _ := strconv.ParseBool(field.Tag.Get("time_utc")); isUTC {
+		l = time.UTC
+	}
+	if locTag := field.Tag.Get("time_location"); locTag != "" {
+		loc, err := time.LoadLocation(locTag)
+		if err != nil {
+			// 由 setTimeField 在绑定时返回错误
+			return nil
+		}
+		l = loc
+	}
+
+	return func(val string, value reflect.Value) error {
+		if val == "" {
+			value.Set(reflect.ValueOf(time.Time{}))
+			return nil
+		}
+		t, err := time.ParseInLocation(timeFormat, val, l)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(t))
+		return nil
+	}
+}
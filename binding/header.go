@@ -0,0 +1,42 @@
+package binding
+
+import (
+	"net/http"
+	"net/textproto"
+	"reflect"
+)
+
+type headerBinding struct{}
+
+func (headerBinding) Name() string {
+	return "header"
+}
+
+func (b headerBinding) Bind(req *http.Request, obj any) error {
+	return b.bind(req, obj, nil)
+}
+
+func (headerBinding) bind(req *http.Request, obj any, cfg *Config) error {
+	if err := mapHeader(obj, req.Header); err != nil {
+		return err
+	}
+	return cfg.validate(obj)
+}
+
+// MapHeader 根据 header 标签将请求头绑定到结构体，不执行参数校验
+func MapHeader(ptr any, h map[string][]string) error {
+	return mapHeader(ptr, h)
+}
+
+func mapHeader(ptr any, h map[string][]string) error {
+	return mappingByPtr(ptr, headerSource(h), "header")
+}
+
+type headerSource map[string][]string
+
+var _ setter = headerSource(nil)
+
+// TrySet 根据规范化后的请求头名称设置字段的值，重复的请求头可以绑定到切片
+func (hs headerSource) TrySet(value reflect.Value, field reflect.StructField, tagValue string, opt setOptions) (bool, error) {
+	return setByForm(value, field, hs, textproto.CanonicalMIMEHeaderKey(tagValue), opt)
+}
@@ -22,30 +22,30 @@ func (jsonBinding) Name() string {
 }
 
 // Bind 绑定参数
-func (jsonBinding) Bind(req *http.Request, obj any) error {
+func (b jsonBinding) Bind(req *http.Request, obj any) error {
 	if req == nil || req.Body == nil {
 		return errors.New("无效请求")
 	}
-	return decodeJSON(req.Body, obj)
+	return decodeAndValidate(b, req.Body, obj, nil)
 }
 
-func (jsonBinding) BindBody(body []byte, obj any) error {
-	return decodeJSON(bytes.NewReader(body), obj)
+func (b jsonBinding) BindBody(body []byte, obj any) error {
+	return decodeAndValidate(b, bytes.NewReader(body), obj, nil)
+}
+
+func (jsonBinding) decode(r io.Reader, obj any, cfg *Config) error {
+	return decodeJSON(r, obj, cfg)
 }
 
 // json 解码
-func decodeJSON(r io.Reader, obj any) error {
+func decodeJSON(r io.Reader, obj any, cfg *Config) error {
 	decoder := json.NewDecoder(r)
-	if EnableDecoderUseNumber {
+	if cfg.decoderUseNumber() {
 		decoder.UseNumber()
 	}
-	if EnableDecoderDisallowUnknownFields {
+	if cfg.decoderDisallowUnknownFields() {
 		decoder.DisallowUnknownFields()
 	}
 
-	if err := decoder.Decode(obj); err != nil {
-		return err
-	}
-
-	return validate(obj)
+	return decoder.Decode(obj)
 }
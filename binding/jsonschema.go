@@ -0,0 +1,421 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema 编译后的 JSON Schema，支持 draft 2020-12 的子集：
+// type、enum、const、required、properties、additionalProperties、items、pattern、
+// minimum、maximum、exclusiveMinimum、exclusiveMaximum、minLength、maxLength、minItems、maxItems，
+// 以及布尔值 schema，不支持的关键字会被忽略
+type Schema struct {
+	reject bool // 布尔值 schema false，任何值都不满足
+
+	types      []string
+	enum       []any
+	hasConst   bool
+	constValue any
+
+	required             []string
+	properties           map[string]*Schema
+	propertyNames        []string // 按名称排序的属性，保证错误顺序稳定
+	additionalProperties *Schema
+	items                *Schema
+
+	pattern          *regexp.Regexp
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	minLength        *int
+	maxLength        *int
+	minItems         *int
+	maxItems         *int
+}
+
+// SchemaError 请求体不满足 JSON Schema 的错误，Pointer 为不满足约束的值的 JSON Pointer（RFC 6901）
+type SchemaError struct {
+	Pointer string
+	Keyword string
+	Message string
+}
+
+func (e SchemaError) Error() string {
+	return e.Pointer + ": " + e.Message
+}
+
+// SchemaErrors 请求体不满足 JSON Schema 的全部错误
+type SchemaErrors []SchemaError
+
+func (errs SchemaErrors) Error() string {
+	var b strings.Builder
+	for i, e := range errs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(e.Error())
+	}
+	return b.String()
+}
+
+// CompileSchema 编译 JSON Schema 文档
+func CompileSchema(data []byte) (*Schema, error) {
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	return compileSchema(doc, "")
+}
+
+// MustCompileSchema 编译 JSON Schema 文档，编译失败时 panic
+func MustCompileSchema(data []byte) *Schema {
+	s, err := CompileSchema(data)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func compileSchema(doc any, at string) (*Schema, error) {
+	switch v := doc.(type) {
+	case bool:
+		return &Schema{reject: !v}, nil
+	case map[string]any:
+		return compileObjectSchema(v, at)
+	default:
+		return nil, fmt.Errorf("%s: schema 必须是对象或布尔值", schemaLocation(at))
+	}
+}
+
+func compileObjectSchema(m map[string]any, at string) (*Schema, error) {
+	s := &Schema{}
+	var err error
+
+	switch t := m["type"].(type) {
+	case nil:
+	case string:
+		s.types = []string{t}
+	case []any:
+		for _, v := range t {
+			name, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: type 必须是字符串或字符串数组", schemaLocation(at))
+			}
+			s.types = append(s.types, name)
+		}
+	default:
+		return nil, fmt.Errorf("%s: type 必须是字符串或字符串数组", schemaLocation(at))
+	}
+
+	if v, ok := m["enum"]; ok {
+		if s.enum, ok = v.([]any); !ok {
+			return nil, fmt.Errorf("%s: enum 必须是数组", schemaLocation(at))
+		}
+	}
+	if v, ok := m["const"]; ok {
+		s.hasConst = true
+		s.constValue = v
+	}
+
+	if v, ok := m["required"]; ok {
+		names, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: required 必须是字符串数组", schemaLocation(at))
+		}
+		for _, name := range names {
+			str, ok := name.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: required 必须是字符串数组", schemaLocation(at))
+			}
+			s.required = append(s.required, str)
+		}
+	}
+
+	if v, ok := m["properties"]; ok {
+		props, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: properties 必须是对象", schemaLocation(at))
+		}
+		s.properties = make(map[string]*Schema, len(props))
+		for name, prop := range props {
+			if s.properties[name], err = compileSchema(prop, at+"/properties/"+escapePointer(name)); err != nil {
+				return nil, err
+			}
+			s.propertyNames = append(s.propertyNames, name)
+		}
+		sort.Strings(s.propertyNames)
+	}
+	if v, ok := m["additionalProperties"]; ok {
+		if s.additionalProperties, err = compileSchema(v, at+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := m["items"]; ok {
+		if s.items, err = compileSchema(v, at+"/items"); err != nil {
+			return nil, err
+		}
+	}
+
+	if v, ok := m["pattern"]; ok {
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: pattern 必须是字符串", schemaLocation(at))
+		}
+		if s.pattern, err = regexp.Compile(str); err != nil {
+			return nil, fmt.Errorf("%s: %w", schemaLocation(at), err)
+		}
+	}
+
+	for keyword, dst := range map[string]**float64{
+		"minimum":          &s.minimum,
+		"maximum":          &s.maximum,
+		"exclusiveMinimum": &s.exclusiveMinimum,
+		"exclusiveMaximum": &s.exclusiveMaximum,
+	} {
+		if v, ok := m[keyword]; ok {
+			f, ok := jsonNumber(v)
+			if !ok {
+				return nil, fmt.Errorf("%s: %s 必须是数字", schemaLocation(at), keyword)
+			}
+			*dst = &f
+		}
+	}
+	for keyword, dst := range map[string]**int{
+		"minLength": &s.minLength,
+		"maxLength": &s.maxLength,
+		"minItems":  &s.minItems,
+		"maxItems":  &s.maxItems,
+	} {
+		if v, ok := m[keyword]; ok {
+			f, ok := jsonNumber(v)
+			if !ok || f < 0 || f != math.Trunc(f) {
+				return nil, fmt.Errorf("%s: %s 必须是非负整数", schemaLocation(at), keyword)
+			}
+			n := int(f)
+			*dst = &n
+		}
+	}
+	return s, nil
+}
+
+// schema 中的位置，用于编译错误信息
+func schemaLocation(at string) string {
+	if at == "" {
+		return "schema"
+	}
+	return "schema " + at
+}
+
+// Validate 校验 json 文档是否满足 schema，不满足时返回包含全部错误的 SchemaErrors
+func (s *Schema) Validate(data []byte) error {
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return err
+	}
+	var errs SchemaErrors
+	s.validate(doc, "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (s *Schema) validate(v any, pointer string, errs *SchemaErrors) {
+	add := func(keyword, format string, args ...any) {
+		*errs = append(*errs, SchemaError{Pointer: pointer, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.reject {
+		add("false", "不允许出现该值")
+		return
+	}
+	if len(s.types) > 0 && !matchesType(v, s.types) {
+		add("type", "类型必须是 %s，实际为 %s", strings.Join(s.types, " 或 "), jsonType(v))
+		// 类型不匹配时其他约束没有意义
+		return
+	}
+	if s.hasConst && !jsonEqual(v, s.constValue) {
+		add("const", "必须等于常量值")
+	}
+	if s.enum != nil && !inEnum(v, s.enum) {
+		add("enum", "必须是枚举值之一")
+	}
+
+	switch v := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.minLength != nil && n < *s.minLength {
+			add("minLength", "长度不能小于 %d", *s.minLength)
+		}
+		if s.maxLength != nil && n > *s.maxLength {
+			add("maxLength", "长度不能大于 %d", *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			add("pattern", "必须匹配正则表达式 %s", s.pattern.String())
+		}
+	case map[string]any:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, SchemaError{Pointer: pointer + "/" + escapePointer(name), Keyword: "required", Message: "缺少必填字段"})
+			}
+		}
+		for _, name := range s.propertyNames {
+			if child, ok := v[name]; ok {
+				s.properties[name].validate(child, pointer+"/"+escapePointer(name), errs)
+			}
+		}
+		if s.additionalProperties != nil {
+			names := make([]string, 0, len(v))
+			for name := range v {
+				if _, ok := s.properties[name]; !ok {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				s.additionalProperties.validate(v[name], pointer+"/"+escapePointer(name), errs)
+			}
+		}
+	case []any:
+		if s.minItems != nil && len(v) < *s.minItems {
+			add("minItems", "元素数量不能小于 %d", *s.minItems)
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			add("maxItems", "元素数量不能大于 %d", *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range v {
+				s.items.validate(item, pointer+"/"+strconv.Itoa(i), errs)
+			}
+		}
+	default:
+		f, ok := jsonNumber(v)
+		if !ok {
+			return
+		}
+		if s.minimum != nil && f < *s.minimum {
+			add("minimum", "不能小于 %v", *s.minimum)
+		}
+		if s.maximum != nil && f > *s.maximum {
+			add("maximum", "不能大于 %v", *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && f <= *s.exclusiveMinimum {
+			add("exclusiveMinimum", "必须大于 %v", *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && f >= *s.exclusiveMaximum {
+			add("exclusiveMaximum", "必须小于 %v", *s.exclusiveMaximum)
+		}
+	}
+}
+
+// 获取 json 数字的值，数字解码为 json.Number
+func jsonNumber(v any) (float64, bool) {
+	n, ok := v.(interface{ Float64() (float64, error) })
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+// 获取 json 值的类型名称
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		if f, ok := jsonNumber(v); ok && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	}
+}
+
+func matchesType(v any, types []string) bool {
+	actual := jsonType(v)
+	for _, t := range types {
+		// 整数同样是 number 类型
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func inEnum(v any, enum []any) bool {
+	for _, e := range enum {
+		if jsonEqual(v, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// 转义 JSON Pointer 中的特殊字符
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+type jsonSchemaBinding struct {
+	schema *Schema
+}
+
+// JSONSchema 使用 JSON Schema 校验请求体的绑定器，校验通过后将请求体解码到 obj 并执行参数校验，
+// obj 为空时只校验请求体
+func JSONSchema(schema *Schema) BindingBody {
+	return jsonSchemaBinding{schema: schema}
+}
+
+func (jsonSchemaBinding) Name() string {
+	return "jsonschema"
+}
+
+func (b jsonSchemaBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("无效请求")
+	}
+	return b.bind(req.Body, obj, nil)
+}
+
+func (b jsonSchemaBinding) BindBody(body []byte, obj any) error {
+	return b.bind(bytes.NewReader(body), obj, nil)
+}
+
+func (b jsonSchemaBinding) bind(r io.Reader, obj any, cfg *Config) error {
+	if obj == nil {
+		return b.decode(r, nil, cfg)
+	}
+	return decodeAndValidate(b, r, obj, cfg)
+}
+
+func (b jsonSchemaBinding) decode(r io.Reader, obj any, cfg *Config) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err = b.schema.Validate(body); err != nil {
+		return err
+	}
+	if obj == nil {
+		return nil
+	}
+	return decodeJSON(bytes.NewReader(body), obj, cfg)
+}
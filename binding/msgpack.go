@@ -0,0 +1,37 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ugorji/go/codec"
+)
+
+type msgpackBinding struct{}
+
+func (msgpackBinding) Name() string {
+	return "msgpack"
+}
+
+func (b msgpackBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("无效请求")
+	}
+	return decodeAndValidate(b, req.Body, obj, nil)
+}
+
+func (b msgpackBinding) BindBody(body []byte, obj any) error {
+	return decodeAndValidate(b, bytes.NewReader(body), obj, nil)
+}
+
+func (msgpackBinding) decode(r io.Reader, obj any, _ *Config) error {
+	return decodeMsgPack(r, obj)
+}
+
+// msgpack 解码
+func decodeMsgPack(r io.Reader, obj any) error {
+	cdc := new(codec.MsgpackHandle)
+	return codec.NewDecoder(r, cdc).Decode(&obj)
+}
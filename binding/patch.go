@@ -0,0 +1,622 @@
+package binding
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/zhangweijie11/zGin/internal/json"
+)
+
+const (
+	MIMEMergePatch = "application/merge-patch+json"
+	MIMEJSONPatch  = "application/json-patch+json"
+)
+
+var (
+	// ErrInvalidPatch 补丁文档无效或无法应用到目标文档
+	ErrInvalidPatch = errors.New("无效的补丁文档")
+
+	// ErrPatchTestFailed JSON Patch 的 test 操作失败
+	ErrPatchTestFailed = errors.New("补丁 test 操作失败")
+)
+
+// MergePatch 将 RFC 7386 JSON Merge Patch 文档应用到 json 文档，值为 null 的字段会被删除
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeDocument(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(mergePatch(target, p))
+}
+
+// 按照 RFC 7386 合并补丁，补丁不是对象时直接替换目标
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+// patchOperation JSON Patch 文档中的操作，Value 为空表示没有 value 成员，null 值为 []byte("null")
+type patchOperation struct {
+	Op    string   `json:"op"`
+	Path  *string  `json:"path"`
+	From  *string  `json:"from"`
+	Value rawValue `json:"value"`
+}
+
+// rawValue 保留 json 原始值，用于区分缺少 value 成员和 value 为 null
+type rawValue []byte
+
+func (v *rawValue) UnmarshalJSON(data []byte) error {
+	*v = append((*v)[:0], data...)
+	return nil
+}
+
+// JSONPatch 将 RFC 6902 JSON Patch 文档应用到 json 文档，任意操作失败时返回错误且不修改文档
+func JSONPatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	var ops []patchOperation
+	if err = json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	for i, op := range ops {
+		if target, err = op.apply(target); err != nil {
+			return nil, fmt.Errorf("第 %d 个补丁操作 %s: %w", i, op.Op, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+// 解码 json 文档，数字解码为 json.Number 避免精度丢失
+func decodeDocument(data []byte) (any, error) {
+	var v any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (op patchOperation) apply(doc any) (any, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("%w: 缺少 path", ErrInvalidPatch)
+	}
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: 缺少 value", ErrInvalidPatch)
+		}
+		value, err := decodeDocument(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		switch op.Op {
+		case "add":
+			return pointerAdd(doc, path, value)
+		case "replace":
+			if _, err = pointerRemove(doc, path); err != nil {
+				return nil, err
+			}
+			return pointerAdd(doc, path, value)
+		}
+		current, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(current, value) {
+			return nil, fmt.Errorf("%w: %s", ErrPatchTestFailed, *op.Path)
+		}
+		return doc, nil
+	case "remove":
+		if len(path) == 0 {
+			return nil, fmt.Errorf("%w: 不能删除根节点", ErrInvalidPatch)
+		}
+		return pointerRemove(doc, path)
+	case "move", "copy":
+		if op.From == nil {
+			return nil, fmt.Errorf("%w: 缺少 from", ErrInvalidPatch)
+		}
+		from, err := parsePointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := pointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			return pointerAdd(doc, path, deepCopy(value))
+		}
+		if *op.Path == *op.From {
+			return doc, nil
+		}
+		if strings.HasPrefix(*op.Path, *op.From+"/") {
+			return nil, fmt.Errorf("%w: 不能移动到自身的子节点", ErrInvalidPatch)
+		}
+		if doc, err = pointerRemove(doc, from); err != nil {
+			return nil, err
+		}
+		return pointerAdd(doc, path, value)
+	default:
+		return nil, fmt.Errorf("%w: 未知的操作 %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// 解析 RFC 6901 JSON Pointer，空字符串表示根节点
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("%w: 无效的 JSON Pointer %q", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// 解析数组下标，allowEnd 为 true 时允许使用 - 或 len 表示数组末尾
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	// 不允许前导零和正负号
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') || token[0] == '+' {
+		return 0, fmt.Errorf("%w: 无效的数组下标 %q", ErrInvalidPatch, token)
+	}
+	if i > length || (i == length && !allowEnd) {
+		return 0, fmt.Errorf("%w: 数组下标 %d 越界", ErrInvalidPatch, i)
+	}
+	return i, nil
+}
+
+func pointerGet(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch v := doc.(type) {
+		case map[string]any:
+			child, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: 路径 %q 不存在", ErrInvalidPatch, token)
+			}
+			doc = child
+		case []any:
+			i, err := arrayIndex(token, len(v), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = v[i]
+		default:
+			return nil, fmt.Errorf("%w: 路径 %q 不存在", ErrInvalidPatch, token)
+		}
+	}
+	return doc, nil
+}
+
+// 修改 path 的父节点，fn 返回修改后的父节点，由于数组长度变化后需要重新赋值，修改后的节点逐层写回
+func pointerUpdate(doc any, path []string, fn func(parent any, key string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	switch v := doc.(type) {
+	case map[string]any:
+		child, ok := v[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: 路径 %q 不存在", ErrInvalidPatch, path[0])
+		}
+		child, err := pointerUpdate(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		v[path[0]] = child
+		return v, nil
+	case []any:
+		i, err := arrayIndex(path[0], len(v), false)
+		if err != nil {
+			return nil, err
+		}
+		child, err := pointerUpdate(v[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = child
+		return v, nil
+	default:
+		return nil, fmt.Errorf("%w: 路径 %q 不存在", ErrInvalidPatch, path[0])
+	}
+}
+
+func pointerAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return pointerUpdate(doc, path, func(parent any, key string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			v[key] = value
+			return v, nil
+		case []any:
+			i, err := arrayIndex(key, len(v), true)
+			if err != nil {
+				return nil, err
+			}
+			v = append(v, nil)
+			copy(v[i+1:], v[i:])
+			v[i] = value
+			return v, nil
+		default:
+			return nil, fmt.Errorf("%w: 路径 %q 的父节点不是对象或数组", ErrInvalidPatch, key)
+		}
+	})
+}
+
+func pointerRemove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	return pointerUpdate(doc, path, func(parent any, key string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			if _, ok := v[key]; !ok {
+				return nil, fmt.Errorf("%w: 路径 %q 不存在", ErrInvalidPatch, key)
+			}
+			delete(v, key)
+			return v, nil
+		case []any:
+			i, err := arrayIndex(key, len(v), false)
+			if err != nil {
+				return nil, err
+			}
+			return append(v[:i], v[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: 路径 %q 不存在", ErrInvalidPatch, key)
+		}
+	})
+}
+
+// 复制 json 节点，避免 copy 操作后两个节点共享同一个对象或数组
+func deepCopy(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, child := range v {
+			m[k] = deepCopy(child)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, child := range v {
+			s[i] = deepCopy(child)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// 比较两个 json 节点是否相等，数字按照数值比较
+func jsonEqual(a, b any) bool {
+	type number interface{ Float64() (float64, error) }
+	if an, ok := a.(number); ok {
+		bn, ok := b.(number)
+		if !ok {
+			return false
+		}
+		af, aErr := an.Float64()
+		bf, bErr := bn.Float64()
+		return aErr == nil && bErr == nil && af == bf
+	}
+
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, av := range a {
+			bv, ok := b[k]
+			if !ok || !jsonEqual(av, bv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// ApplyMergePatch 将 JSON Merge Patch 应用到 obj 并重新执行参数校验，obj 必须是非空指针，校验失败时不修改 obj
+func (cfg *Config) ApplyMergePatch(patch []byte, obj any) error {
+	return cfg.applyPatch(obj, func(doc []byte) ([]byte, error) {
+		return MergePatch(doc, patch)
+	})
+}
+
+// ApplyJSONPatch 将 JSON Patch 应用到 obj 并重新执行参数校验，obj 必须是非空指针，校验失败时不修改 obj
+func (cfg *Config) ApplyJSONPatch(patch []byte, obj any) error {
+	return cfg.applyPatch(obj, func(doc []byte) ([]byte, error) {
+		return JSONPatch(doc, patch)
+	})
+}
+
+// 将 obj 编码为 json 文档后应用补丁，再解码到 obj 的副本中，补丁删除的字段恢复为零值，
+// json 编码时忽略的字段（json:"-" 和未导出字段）保持原值
+func (cfg *Config) applyPatch(obj any, patch func(doc []byte) ([]byte, error)) error {
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("补丁目标必须是非空指针")
+	}
+	doc, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	patchedDoc, err := patch(doc)
+	if err != nil {
+		return err
+	}
+	before, err := decodeDocument(doc)
+	if err != nil {
+		return err
+	}
+	after, err := decodeDocument(patchedDoc)
+	if err != nil {
+		return err
+	}
+
+	patched := reflect.New(rv.Elem().Type())
+	patched.Elem().Set(rv.Elem())
+	detachValue(patched.Elem())
+	if err = decodeJSON(bytes.NewReader(patchedDoc), patched.Interface(), cfg); err != nil {
+		return err
+	}
+	if err = clearRemoved(patched.Elem(), before, after); err != nil {
+		return err
+	}
+	if err = cfg.validate(patched.Interface()); err != nil {
+		return err
+	}
+	rv.Elem().Set(patched.Elem())
+	return nil
+}
+
+// detachValue 复制 json 解码会写入的指针、切片和映射，使解码副本时不会修改原值
+func detachValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		n := reflect.New(v.Type().Elem())
+		n.Elem().Set(v.Elem())
+		detachValue(n.Elem())
+		v.Set(n)
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		n := reflect.New(v.Elem().Type()).Elem()
+		n.Set(v.Elem())
+		detachValue(n)
+		v.Set(n)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			sf := t.Field(i)
+			if sf.Tag.Get("json") == "-" || (!sf.IsExported() && !sf.Anonymous) {
+				continue
+			}
+			detachValue(v.Field(i))
+		}
+	case reflect.Slice:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		n := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(n, v)
+		for i := 0; i < n.Len(); i++ {
+			detachValue(n.Index(i))
+		}
+		v.Set(n)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			detachValue(v.Index(i))
+		}
+	case reflect.Map:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		n := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			detachValue(elem)
+			n.SetMapIndex(iter.Key(), elem)
+		}
+		v.Set(n)
+	}
+}
+
+// clearRemoved 对比补丁前后的文档，将补丁删除或设置为 null 的成员恢复为零值，
+// json 解码只会写入文档中存在的成员，不处理时被删除的成员会保留原值
+func clearRemoved(v reflect.Value, before, after any) error {
+	if after == nil {
+		if before != nil && v.CanSet() {
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch b := before.(type) {
+	case map[string]any:
+		a, ok := after.(map[string]any)
+		if !ok {
+			return nil
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(v.Type())
+			for name, bv := range b {
+				index, ok := fields[name]
+				if !ok {
+					continue
+				}
+				f, err := v.FieldByIndexErr(index)
+				if err != nil {
+					continue
+				}
+				av, ok := a[name]
+				if !ok {
+					av = nil
+				}
+				if err = clearRemoved(f, bv, av); err != nil {
+					return err
+				}
+			}
+		case reflect.Map:
+			// 解码时映射的值会整体替换，只需要删除补丁中已删除的键
+			for name := range b {
+				if _, ok := a[name]; ok {
+					continue
+				}
+				key, err := jsonMapKey(v.Type().Key(), name)
+				if err != nil {
+					return err
+				}
+				v.SetMapIndex(key, reflect.Value{})
+			}
+		}
+	case []any:
+		a, ok := after.([]any)
+		if !ok || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) {
+			return nil
+		}
+		// 解码切片时会复用已有元素，逐个元素清除被删除的成员
+		for i := 0; i < len(b) && i < len(a) && i < v.Len(); i++ {
+			if err := clearRemoved(v.Index(i), b[i], a[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonFields 获取结构体 json 成员名称对应的字段索引，按照 encoding/json 的规则展开匿名字段，层级较浅的字段优先
+func jsonFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, _ := head(tag, ",")
+			fieldIndex := append(append([]int(nil), index...), i)
+			if sf.Anonymous && name == "" {
+				ft := sf.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft, fieldIndex)
+					continue
+				}
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			if old, ok := fields[name]; !ok || len(fieldIndex) < len(old) {
+				fields[name] = fieldIndex
+			}
+		}
+	}
+	walk(t, nil)
+	return fields
+}
+
+// jsonMapKey 按照 encoding/json 的规则将 json 成员名称转换为映射的键
+func jsonMapKey(t reflect.Type, name string) (reflect.Value, error) {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		key := reflect.New(t)
+		if err := key.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(name)); err != nil {
+			return reflect.Value{}, err
+		}
+		return key.Elem(), nil
+	}
+
+	key := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		key.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, 64)
+		if err != nil || key.OverflowInt(n) {
+			return reflect.Value{}, fmt.Errorf("无效的映射键: %s", name)
+		}
+		key.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, 64)
+		if err != nil || key.OverflowUint(n) {
+			return reflect.Value{}, fmt.Errorf("无效的映射键: %s", name)
+		}
+		key.SetUint(n)
+	default:
+		return reflect.Value{}, fmt.Errorf("不支持的映射键类型: %s", t)
+	}
+	return key, nil
+}
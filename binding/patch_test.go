@@ -0,0 +1,85 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+)
+
+type patchAddress struct {
+	City   string `json:"city"`
+	Street string `json:"street,omitempty"`
+	secret string
+}
+
+type patchUser struct {
+	ID      int               `json:"-"`
+	Hash    string            `json:"-"`
+	Name    string            `json:"name"`
+	Email   string            `json:"email,omitempty"`
+	Addr    *patchAddress     `json:"addr,omitempty"`
+	Tags    []patchAddress    `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+	version int
+}
+
+func TestApplyMergePatchKeepsNonJSONFields(t *testing.T) {
+	user := patchUser{ID: 7, Hash: "secret", Name: "gin", Email: "gin@example.com", version: 3}
+	if err := (*Config)(nil).ApplyMergePatch([]byte(`{"email":null}`), &user); err != nil {
+		t.Fatal(err)
+	}
+	want := patchUser{ID: 7, Hash: "secret", Name: "gin", version: 3}
+	if !reflect.DeepEqual(user, want) {
+		t.Fatalf("got %+v, want %+v", user, want)
+	}
+}
+
+func TestApplyMergePatchClearsNestedMembers(t *testing.T) {
+	user := patchUser{
+		ID:     7,
+		Name:   "gin",
+		Addr:   &patchAddress{City: "beijing", Street: "main", secret: "x"},
+		Labels: map[string]string{"a": "1", "b": "2"},
+	}
+	addr := user.Addr
+	if err := (*Config)(nil).ApplyMergePatch([]byte(`{"addr":{"street":null},"labels":{"a":null}}`), &user); err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 7 || *user.Addr != (patchAddress{City: "beijing", secret: "x"}) {
+		t.Fatalf("got %+v, addr %+v", user, *user.Addr)
+	}
+	if !reflect.DeepEqual(user.Labels, map[string]string{"b": "2"}) {
+		t.Fatalf("got labels %v", user.Labels)
+	}
+	if addr.Street != "main" {
+		t.Fatal("补丁修改了原来的地址")
+	}
+}
+
+func TestApplyJSONPatchClearsSliceElements(t *testing.T) {
+	user := patchUser{Tags: []patchAddress{{City: "a", Street: "s"}, {City: "b"}}}
+	tags := user.Tags
+	if err := (*Config)(nil).ApplyJSONPatch([]byte(`[{"op":"remove","path":"/tags/0"}]`), &user); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(user.Tags, []patchAddress{{City: "b"}}) {
+		t.Fatalf("got tags %+v", user.Tags)
+	}
+	if tags[0].Street != "s" {
+		t.Fatal("补丁修改了原来的切片")
+	}
+}
+
+type patchValidated struct {
+	ID   int    `json:"-"`
+	Name string `json:"name" binding:"required"`
+}
+
+func TestApplyMergePatchValidationFailureKeepsObject(t *testing.T) {
+	obj := patchValidated{ID: 1, Name: "gin"}
+	if err := (*Config)(nil).ApplyMergePatch([]byte(`{"name":null}`), &obj); err == nil {
+		t.Fatal("缺少 name 时应该校验失败")
+	}
+	if obj != (patchValidated{ID: 1, Name: "gin"}) {
+		t.Fatalf("got %+v", obj)
+	}
+}
@@ -0,0 +1,41 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type protobufBinding struct{}
+
+func (protobufBinding) Name() string {
+	return "protobuf"
+}
+
+func (b protobufBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("无效请求")
+	}
+	return decodeAndValidate(b, req.Body, obj, nil)
+}
+
+// BindBody protobuf 解码，obj 必须实现 proto.Message 接口，
+// 和其他绑定器一样解码后执行参数校验，protoc 生成的结构体没有 binding 标签时校验不会产生错误
+func (b protobufBinding) BindBody(body []byte, obj any) error {
+	return decodeAndValidate(b, bytes.NewReader(body), obj, nil)
+}
+
+func (protobufBinding) decode(r io.Reader, obj any, _ *Config) error {
+	msg, ok := obj.(proto.Message)
+	if !ok {
+		return errors.New("obj 未实现 proto.Message 接口")
+	}
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(buf, msg)
+}
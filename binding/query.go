@@ -0,0 +1,20 @@
+package binding
+
+import "net/http"
+
+type queryBinding struct{}
+
+func (queryBinding) Name() string {
+	return "query"
+}
+
+func (b queryBinding) Bind(req *http.Request, obj any) error {
+	return b.bind(req, obj, nil)
+}
+
+func (queryBinding) bind(req *http.Request, obj any, cfg *Config) error {
+	if err := mapForm(obj, req.URL.Query()); err != nil {
+		return err
+	}
+	return cfg.validate(obj)
+}
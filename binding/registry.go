@@ -0,0 +1,77 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedMediaType 请求体的 Content-Type 没有对应的绑定器
+var ErrUnsupportedMediaType = errors.New("不支持的媒体类型")
+
+var (
+	registryMu sync.RWMutex
+	// 以 MIME 类型或结构化后缀（例如 +json）为 key 的绑定器
+	registry = map[string]Binding{
+		MIMEJSON:              JSON,
+		MIMEXML:               XML,
+		MIMEXML2:              XML,
+		MIMEPROTOBUF:          ProtoBuf,
+		MIMEMSGPACK:           MsgPack,
+		MIMEMSGPACK2:          MsgPack,
+		MIMEYAML:              YAML,
+		MIMEYAML2:             YAML,
+		MIMETOML:              TOML,
+		MIMEPOSTForm:          Form,
+		MIMEMultipartPOSTForm: Form,
+		"+json":               JSON,
+		"+xml":                XML,
+		"+yaml":               YAML,
+	}
+)
+
+// Register 注册 MIME 类型对应的绑定器，mime 以 + 开头时表示结构化后缀，例如 +json 可以匹配 application/vnd.api+json，
+// 已经注册过的 MIME 类型会被覆盖
+func Register(mime string, b Binding) {
+	if mime == "" || b == nil {
+		panic("MIME 类型和绑定器不能为空")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(mime)] = b
+}
+
+// Lookup 查找 MIME 类型对应的绑定器，优先完全匹配，其次匹配结构化后缀
+func Lookup(mime string) (Binding, bool) {
+	mime = strings.ToLower(mime)
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if b, ok := registry[mime]; ok {
+		return b, true
+	}
+	if i := strings.LastIndexByte(mime, '+'); i > 0 {
+		if b, ok := registry[mime[i:]]; ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// unsupportedBinding 没有对应绑定器的 Content-Type 使用的绑定器，绑定时总是返回 ErrUnsupportedMediaType
+type unsupportedBinding struct {
+	contentType string
+}
+
+func (unsupportedBinding) Name() string {
+	return "unsupported"
+}
+
+func (b unsupportedBinding) Bind(*http.Request, any) error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, b.contentType)
+}
+
+func (b unsupportedBinding) BindBody([]byte, any) error {
+	return b.Bind(nil, nil)
+}
@@ -0,0 +1,37 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+type tomlBinding struct{}
+
+func (tomlBinding) Name() string {
+	return "toml"
+}
+
+func (b tomlBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("无效请求")
+	}
+	return decodeAndValidate(b, req.Body, obj, nil)
+}
+
+func (b tomlBinding) BindBody(body []byte, obj any) error {
+	return decodeAndValidate(b, bytes.NewReader(body), obj, nil)
+}
+
+func (tomlBinding) decode(r io.Reader, obj any, _ *Config) error {
+	return decodeToml(r, obj)
+}
+
+// toml 解码
+func decodeToml(r io.Reader, obj any) error {
+	decoder := toml.NewDecoder(r)
+	return decoder.Decode(obj)
+}
@@ -0,0 +1,220 @@
+package binding
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+)
+
+// RegisterTranslationsFunc 向参数验证器引擎注册某个语言的翻译，
+// 通常为 github.com/go-playground/validator/v10/translations 下各语言包的 RegisterDefaultTranslations
+type RegisterTranslationsFunc func(v *validator.Validate, trans ut.Translator) error
+
+// FieldErrors 字段级别的参数校验错误，key 为使用 json/form 标签名称的字段路径，例如 address.city、items[0].sku
+type FieldErrors map[string]string
+
+// Error 按字段路径排序后组装错误信息
+func (errs FieldErrors) Error() string {
+	fields := make([]string, 0, len(errs))
+	for field := range errs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var b strings.Builder
+	for _, field := range fields {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(field + ": " + errs[field])
+	}
+	return b.String()
+}
+
+// Translator 将参数校验错误翻译为字段级别的错误信息，默认只支持英文，
+// 默认参数验证器在实例化时注册翻译，其他参数验证器需要调用 AddValidator 注册
+var Translator = NewTranslator(en.New(), entranslations.RegisterDefaultTranslations)
+
+// ValidationTranslator 基于 universal-translator 的参数校验错误翻译器
+//
+// 翻译在 NewTranslator、Register 和 AddValidator 中立即注册到参数验证器引擎，validator 注册翻译不是并发安全的，
+// 这些方法需要在处理请求之前调用，翻译时只读取已注册的翻译
+type ValidationTranslator struct {
+	mu        sync.RWMutex
+	fallback  locales.Translator
+	locales   []locales.Translator
+	registers map[string]RegisterTranslationsFunc
+	// 已经注册了全部语言翻译的参数验证器引擎，翻译文本保存在 ut.Translator 中，每个引擎使用独立的 UniversalTranslator
+	validators map[*validator.Validate]*ut.UniversalTranslator
+}
+
+// NewTranslator 实例化参数校验错误翻译器，fallback 为找不到客户端语言时使用的默认语言，
+// 翻译立即注册到默认参数验证器 Validator，注册失败时 panic
+func NewTranslator(fallback locales.Translator, register RegisterTranslationsFunc) *ValidationTranslator {
+	t := &ValidationTranslator{
+		fallback:   fallback,
+		locales:    []locales.Translator{fallback},
+		registers:  map[string]RegisterTranslationsFunc{fallback.Locale(): register},
+		validators: make(map[*validator.Validate]*ut.UniversalTranslator),
+	}
+	if err := t.AddValidator(Validator); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Register 注册某个语言的翻译，翻译立即注册到全部已添加的参数验证器引擎，需要在处理请求之前调用
+func (t *ValidationTranslator) Register(trans locales.Translator, register RegisterTranslationsFunc) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.registers[trans.Locale()]; !ok {
+		t.locales = append(t.locales, trans)
+	}
+	t.registers[trans.Locale()] = register
+	for v, uni := range t.validators {
+		if err := uni.AddTranslator(trans, true); err != nil {
+			return err
+		}
+		translator, _ := uni.GetTranslator(trans.Locale())
+		if err := register(v, translator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddValidator 向参数验证器引擎注册全部语言的翻译，需要在处理请求之前调用，
+// 只支持基于 go-playground/validator 的参数验证器，其他参数验证器直接忽略
+func (t *ValidationTranslator) AddValidator(sv StructValidator) error {
+	v, ok := validatorEngine(sv)
+	if !ok {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.validators[v] != nil {
+		return nil
+	}
+	uni := ut.New(t.fallback, t.locales...)
+	for _, locale := range t.locales {
+		translator, _ := uni.GetTranslator(locale.Locale())
+		if err := t.registers[locale.Locale()](v, translator); err != nil {
+			return err
+		}
+	}
+	t.validators[v] = uni
+	return nil
+}
+
+// Translate 将参数校验错误翻译为 langs 中第一个支持的语言，langs 通常来自 Accept-Language 请求头，
+// err 不是参数校验错误时返回 nil
+func (t *ValidationTranslator) Translate(err error, langs ...string) FieldErrors {
+	return t.TranslateWithValidator(Validator, err, langs...)
+}
+
+// TranslateWithValidator 翻译指定参数验证器返回的参数校验错误
+func (t *ValidationTranslator) TranslateWithValidator(sv StructValidator, err error, langs ...string) FieldErrors {
+	// JSON Schema 的校验错误不依赖参数验证器，字段路径为 JSON Pointer
+	var schemaErrs SchemaErrors
+	if errors.As(err, &schemaErrs) {
+		fieldErrors := make(FieldErrors, len(schemaErrs))
+		for _, e := range schemaErrs {
+			if msg, ok := fieldErrors[e.Pointer]; ok {
+				fieldErrors[e.Pointer] = msg + "; " + e.Message
+				continue
+			}
+			fieldErrors[e.Pointer] = e.Message
+		}
+		return fieldErrors
+	}
+
+	v, ok := validatorEngine(sv)
+	if !ok {
+		return nil
+	}
+	trans := t.translator(v, langs)
+
+	fieldErrors := make(FieldErrors)
+	translateError(fieldErrors, "", err, trans)
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	return fieldErrors
+}
+
+// 查找语言对应的翻译器，参数验证器引擎没有注册翻译时返回 nil，错误信息不翻译
+func (t *ValidationTranslator) translator(v *validator.Validate, langs []string) ut.Translator {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	uni := t.validators[v]
+	if uni == nil {
+		return nil
+	}
+	trans, _ := uni.FindTranslator(langs...)
+	return trans
+}
+
+// 获取参数验证器引擎，只支持基于 go-playground/validator 的参数验证器
+func validatorEngine(sv StructValidator) (*validator.Validate, bool) {
+	if sv == nil {
+		return nil, false
+	}
+	v, ok := sv.Engine().(*validator.Validate)
+	return v, ok
+}
+
+// 去掉命名空间开头的结构体名称，例如 CreateUser.address.city -> address.city，
+// 默认参数验证器的错误记录了根类型，匿名结构体的命名空间没有结构体名称；
+// 其他参数验证器的错误没有根类型，只能比较两种命名空间的第一段，匿名结构体中没有标签的嵌套字段无法区分
+func trimRootNamespace(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if rfe, ok := fe.(rootedFieldError); ok {
+		if rfe.root == "" {
+			return ns
+		}
+		return strings.TrimPrefix(ns, rfe.root+".")
+	}
+
+	root, field, found := strings.Cut(ns, ".")
+	if !found {
+		return ns
+	}
+	if structRoot, _, _ := strings.Cut(fe.StructNamespace(), "."); structRoot != root {
+		return ns
+	}
+	return field
+}
+
+// 递归翻译参数校验错误，prefix 为切片元素的下标前缀
+func translateError(fieldErrors FieldErrors, prefix string, err error, trans ut.Translator) {
+	var sliceErrs SliceValidationError
+	if errors.As(err, &sliceErrs) {
+		for i, e := range sliceErrs {
+			if e != nil {
+				translateError(fieldErrors, prefix+"["+strconv.Itoa(i)+"]", e, trans)
+			}
+		}
+		return
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return
+	}
+	for _, fe := range validationErrs {
+		field := trimRootNamespace(fe)
+		if prefix != "" {
+			field = prefix + "." + field
+		}
+		fieldErrors[field] = fe.Translate(trans)
+	}
+}
@@ -0,0 +1,68 @@
+package binding
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type translatorAddress struct {
+	City string `binding:"required"`
+	Zip  string `json:"zip" binding:"required"`
+}
+
+type translatorUser struct {
+	Name string            `json:"name" binding:"required"`
+	Addr translatorAddress `json:"address"`
+}
+
+func TestTranslateFieldPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  any
+		want []string
+	}{
+		{"named", &translatorUser{}, []string{"name", "address.City", "address.zip"}},
+		{"anonymous", &struct {
+			Name string `json:"name" binding:"required"`
+		}{}, []string{"name"}},
+		{"anonymous untagged field", &struct {
+			Name string `binding:"required"`
+		}{}, []string{"Name"}},
+		{"anonymous untagged nested", &struct {
+			Addr translatorAddress
+		}{}, []string{"Addr.City", "Addr.zip"}},
+		{"slice", []translatorAddress{{City: "a", Zip: "1"}, {}}, []string{"[1].City", "[1].zip"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validator.ValidateStruct(tt.obj)
+			if err == nil {
+				t.Fatal("应该校验失败")
+			}
+			got := Translator.Translate(err, "en")
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want fields %v", got, tt.want)
+			}
+			for _, field := range tt.want {
+				if got[field] == "" {
+					t.Fatalf("got %v, missing field %s", got, field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationErrorsKeepType(t *testing.T) {
+	err := Validator.ValidateStruct(&translatorUser{})
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		t.Fatalf("got %T", err)
+	}
+	var fe validator.FieldError = errs[0]
+	if fe.Tag() != "required" || !errors.As(err, new(validator.ValidationErrors)) {
+		t.Fatal(reflect.TypeOf(fe))
+	}
+}
@@ -0,0 +1,18 @@
+package binding
+
+type uriBinding struct{}
+
+func (uriBinding) Name() string {
+	return "uri"
+}
+
+func (b uriBinding) BindUri(m map[string][]string, obj any) error {
+	return b.bindUri(m, obj, nil)
+}
+
+func (uriBinding) bindUri(m map[string][]string, obj any, cfg *Config) error {
+	if err := mapURI(obj, m); err != nil {
+		return err
+	}
+	return cfg.validate(obj)
+}
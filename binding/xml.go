@@ -0,0 +1,85 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// XMLMaxDepth XML 请求体允许的最大嵌套深度，防止深度嵌套的请求体耗尽资源，小于等于 0 时不限制
+var XMLMaxDepth = 100
+
+// XMLAllowDTD 是否允许 XML 请求体中包含 DTD 等指令（<!DOCTYPE>、<!ENTITY>），默认不允许，防止实体扩展攻击
+var XMLAllowDTD = false
+
+var (
+	// ErrXMLTooDeep XML 请求体嵌套深度超过限制
+	ErrXMLTooDeep = errors.New("xml: 嵌套深度超过限制")
+
+	// ErrXMLDirective XML 请求体中包含不允许的指令
+	ErrXMLDirective = errors.New("xml: 不允许使用 DTD 指令")
+)
+
+type xmlBinding struct{}
+
+func (xmlBinding) Name() string {
+	return "xml"
+}
+
+func (b xmlBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("无效请求")
+	}
+	return decodeAndValidate(b, req.Body, obj, nil)
+}
+
+func (b xmlBinding) BindBody(body []byte, obj any) error {
+	return decodeAndValidate(b, bytes.NewReader(body), obj, nil)
+}
+
+func (xmlBinding) decode(r io.Reader, obj any, cfg *Config) error {
+	return decodeXML(r, obj, cfg)
+}
+
+// xml 解码
+func decodeXML(r io.Reader, obj any, cfg *Config) error {
+	decoder := xml.NewTokenDecoder(&limitedTokenReader{
+		d:        xml.NewDecoder(r),
+		maxDepth: cfg.xmlMaxDepth(),
+		allowDTD: cfg.xmlAllowDTD(),
+	})
+	return decoder.Decode(obj)
+}
+
+// limitedTokenReader 在读取 XML 标记时限制嵌套深度并拦截指令，
+// 返回未经处理的原始标记，由外层解码器负责命名空间转换和标签匹配校验
+type limitedTokenReader struct {
+	d        *xml.Decoder
+	depth    int
+	maxDepth int
+	allowDTD bool
+}
+
+func (r *limitedTokenReader) Token() (xml.Token, error) {
+	t, err := r.d.RawToken()
+	if err != nil {
+		return t, err
+	}
+	switch t.(type) {
+	case xml.StartElement:
+		r.depth++
+		if r.maxDepth > 0 && r.depth > r.maxDepth {
+			return nil, fmt.Errorf("%w: %d", ErrXMLTooDeep, r.maxDepth)
+		}
+	case xml.EndElement:
+		r.depth--
+	case xml.Directive:
+		if !r.allowDTD {
+			return nil, ErrXMLDirective
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,37 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlBinding struct{}
+
+func (yamlBinding) Name() string {
+	return "yaml"
+}
+
+func (b yamlBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("无效请求")
+	}
+	return decodeAndValidate(b, req.Body, obj, nil)
+}
+
+func (b yamlBinding) BindBody(body []byte, obj any) error {
+	return decodeAndValidate(b, bytes.NewReader(body), obj, nil)
+}
+
+func (yamlBinding) decode(r io.Reader, obj any, _ *Config) error {
+	return decodeYAML(r, obj)
+}
+
+// yaml 解码
+func decodeYAML(r io.Reader, obj any) error {
+	decoder := yaml.NewDecoder(r)
+	return decoder.Decode(obj)
+}
@@ -0,0 +1,133 @@
+package gin
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultMaxBodySize             = 32 << 20 // 请求体默认允许的最大长度，32 MB
+	defaultMaxDecompressedBodySize = 32 << 20 // 请求体解压后默认允许的最大长度，32 MB
+)
+
+// MaxBodySize 设置当前路由组请求体允许的最大长度，覆盖 Engine.MaxBodySize，小于等于 0 时不限制，
+// 需要在读取请求体之前执行，超过限制时绑定返回 413
+func MaxBodySize(n int64) HandlerFunc {
+	return func(c *Context) {
+		c.maxBodySize = n
+		c.Next()
+	}
+}
+
+// requestBody 包装请求体，在第一次读取时根据 Context 当前的长度限制和 Content-Encoding 构建实际的读取器，
+// 使路由组的中间件可以在读取请求体之前调整长度限制
+type requestBody struct {
+	c       *Context
+	body    io.ReadCloser
+	r       io.Reader
+	decoder io.Closer
+	err     error
+}
+
+func (b *requestBody) Read(p []byte) (int, error) {
+	if b.r == nil && b.err == nil {
+		b.r, b.err = b.reader()
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.r.Read(p)
+}
+
+func (b *requestBody) Close() error {
+	if b.decoder != nil {
+		_ = b.decoder.Close()
+	}
+	return b.body.Close()
+}
+
+// 构建请求体的读取器，先限制原始请求体的长度，再解压并限制解压后的长度
+func (b *requestBody) reader() (io.Reader, error) {
+	c := b.c
+	var body io.ReadCloser = b.body
+	if c.maxBodySize > 0 {
+		body = http.MaxBytesReader(c.Writer, body, c.maxBodySize)
+	}
+
+	var r io.ReadCloser
+	var err error
+	switch c.contentEncoding {
+	case "":
+		return body, nil
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(body)
+	case "deflate":
+		r, err = zlib.NewReader(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.decoder = r
+
+	limit := c.engine.MaxDecompressedBodySize
+	if limit <= 0 {
+		limit = defaultMaxDecompressedBodySize
+	}
+	return &decompressedBody{r: r, n: limit, limit: limit}, nil
+}
+
+// decompressedBody 限制解压后的请求体长度，防止压缩炸弹
+type decompressedBody struct {
+	r     io.Reader
+	n     int64 // 剩余可读取的长度
+	limit int64
+}
+
+func (d *decompressedBody) Read(p []byte) (int, error) {
+	if d.n < 0 {
+		return 0, &http.MaxBytesError{Limit: d.limit}
+	}
+	// 多读取一个字节用于判断是否超过限制
+	if int64(len(p)) > d.n+1 {
+		p = p[:d.n+1]
+	}
+	n, err := d.r.Read(p)
+	if int64(n) <= d.n {
+		d.n -= int64(n)
+		return n, err
+	}
+	n = int(d.n)
+	d.n = -1
+	return n, &http.MaxBytesError{Limit: d.limit}
+}
+
+// 获取请求体允许的最大长度，为 0 时使用默认的 32 MB，返回值小于 0 时不限制
+func (engine *Engine) maxBodySize() int64 {
+	if engine.MaxBodySize == 0 {
+		return defaultMaxBodySize
+	}
+	return engine.MaxBodySize
+}
+
+// 包装请求体，设置请求体的长度限制，请求体使用 gzip 或 deflate 压缩时透明解压
+func (engine *Engine) wrapRequestBody(c *Context) {
+	c.maxBodySize = engine.maxBodySize()
+	c.contentEncoding = ""
+	req := c.Request
+	if req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+	if !engine.DisableRequestDecompression {
+		switch encoding := strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding"))); encoding {
+		case "gzip", "x-gzip", "deflate":
+			c.contentEncoding = encoding
+			// 解压后的长度未知，删除压缩相关的请求头避免后续处理器重复解压
+			req.Header.Del("Content-Encoding")
+			req.ContentLength = -1
+		}
+	}
+	req.Body = &requestBody{c: c, body: req.Body}
+}
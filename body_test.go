@@ -0,0 +1,76 @@
+package gin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zhangweijie11/zGin/binding"
+)
+
+func init() {
+	SetMode(TestMode)
+}
+
+// spaceReader 产生指定长度的空白字符，避免在测试中分配超大的请求体
+type spaceReader struct{ n int64 }
+
+func (r *spaceReader) Read(p []byte) (int, error) {
+	if r.n <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.n {
+		p = p[:r.n]
+	}
+	for i := range p {
+		p[i] = ' '
+	}
+	r.n -= int64(len(p))
+	return len(p), nil
+}
+
+func TestDefaultMaxBodySize(t *testing.T) {
+	tests := []struct {
+		name string
+		size int64
+		opts []OptionFunc
+		want int
+	}{
+		{"default limit", defaultMaxBodySize + 1, nil, http.StatusRequestEntityTooLarge},
+		{"within default limit", defaultMaxBodySize, nil, http.StatusOK},
+		{"unlimited", defaultMaxBodySize + 1, []OptionFunc{WithMaxBodySize(-1)}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.opts...)
+			r.POST("/", func(c *Context) {
+				if _, err := c.GetRawData(); err != nil {
+					c.AbortWithStatus(bindErrorStatus(err))
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", &spaceReader{n: tt.size}))
+			if w.Code != tt.want {
+				t.Fatalf("got status %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultMaxBodySizeShouldBindBodyWith(t *testing.T) {
+	r := New()
+	r.POST("/", func(c *Context) {
+		var obj map[string]any
+		if err := c.ShouldBindBodyWith(&obj, binding.JSON); err != nil {
+			c.AbortWithStatus(bindErrorStatus(err))
+		}
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", &spaceReader{n: defaultMaxBodySize + 1}))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("got status %d", w.Code)
+	}
+}
@@ -14,23 +14,25 @@ import (
 )
 
 type Context struct {
-	engine       *Engine
-	params       *Params
-	skippedNodes *[]skippedNode
-	Request      *http.Request
-	Writer       ResponseWriter
-	index        int8           // 处理器索引
-	handlers     HandlersChain  // 处理器链路
-	Keys         map[string]any // 处理请求上下文的键值对
-	Errors       errorMsgs      // 错误信息
-	writermem    responseWriter // 自定义响应写入
-	Params       Params         //
-	fullPath     string         // 完整路由
-	Accepted     []string       // 定义用于内容协商的手动接受格式列表
-	queryCache   url.Values     // 缓存来自 c.Request.URL.Query（） 的查询结果
-	formCache    url.Values     // 缓存 c.Request.PostForm，其中包含来自 POST、PATCH 或 PUT 正文参数的解析表单数据
-	sameSite     http.SameSite  // 允许服务器定义 cookie 属性，使其成为浏览器与跨站点请求一起发送此 cookie
-	mu           sync.RWMutex   // 获取请求上下文时加锁
+	engine          *Engine
+	params          *Params
+	skippedNodes    *[]skippedNode
+	Request         *http.Request
+	Writer          ResponseWriter
+	index           int8           // 处理器索引
+	handlers        HandlersChain  // 处理器链路
+	Keys            map[string]any // 处理请求上下文的键值对
+	Errors          errorMsgs      // 错误信息
+	writermem       responseWriter // 自定义响应写入
+	Params          Params         //
+	fullPath        string         // 完整路由
+	Accepted        []string       // 定义用于内容协商的手动接受格式列表
+	queryCache      url.Values     // 缓存来自 c.Request.URL.Query（） 的查询结果
+	formCache       url.Values     // 缓存 c.Request.PostForm，其中包含来自 POST、PATCH 或 PUT 正文参数的解析表单数据
+	sameSite        http.SameSite  // 允许服务器定义 cookie 属性，使其成为浏览器与跨站点请求一起发送此 cookie
+	maxBodySize     int64          // 请求体允许的最大长度，小于等于 0 时不限制
+	contentEncoding string         // 请求体的压缩格式，读取请求体时透明解压
+	mu              sync.RWMutex   // 获取请求上下文时加锁
 }
 
 const abortIndex = math.MaxInt8 >> 1
@@ -45,6 +47,9 @@ const (
 	MIMEYAML              = binding.MIMEYAML
 	MIMEYAML2             = binding.MIMEYAML2
 	MIMETOML              = binding.MIMETOML
+	MIMEPROTOBUF          = binding.MIMEPROTOBUF
+	MIMEMSGPACK           = binding.MIMEMSGPACK
+	MIMEMSGPACK2          = binding.MIMEMSGPACK2
 )
 
 // BodyBytesKey 默认请求上下文字节数据
@@ -87,6 +92,55 @@ func (c *Context) requestHeader(key string) string {
 	return c.Request.Header.Get(key)
 }
 
+// GetHeader 获取请求头中的值
+func (c *Context) GetHeader(key string) string {
+	return c.requestHeader(key)
+}
+
+// Header 设置响应头，如果 value 为空则删除该响应头
+func (c *Context) Header(key, value string) {
+	if value == "" {
+		c.Writer.Header().Del(key)
+		return
+	}
+	c.Writer.Header().Set(key, value)
+}
+
+// GetRawData 读取原始请求体数据，请求体只能读取一次
+func (c *Context) GetRawData() ([]byte, error) {
+	if c.Request.Body == nil {
+		return nil, errors.New("无效请求")
+	}
+	return io.ReadAll(c.Request.Body)
+}
+
+// FullPath 获取匹配到的完整路由，例如 /user/:id，未匹配到路由时返回空字符串
+func (c *Context) FullPath() string {
+	return c.fullPath
+}
+
+// HandlerName 获取主处理器的名称，例如处理器为 handleGetUsers()，则返回 "main.handleGetUsers"
+func (c *Context) HandlerName() string {
+	return nameOfFunction(c.handlers.Last())
+}
+
+// HandlerNames 按顺序获取当前处理器链路中全部处理器的名称
+func (c *Context) HandlerNames() []string {
+	hn := make([]string, 0, len(c.handlers))
+	for _, val := range c.handlers {
+		if val == nil {
+			continue
+		}
+		hn = append(hn, nameOfFunction(val))
+	}
+	return hn
+}
+
+// Handler 获取主处理器
+func (c *Context) Handler() HandlerFunc {
+	return c.handlers.Last()
+}
+
 // RemoteIP 获取远程 发起请求的 IP
 func (c *Context) RemoteIP() string {
 	ip, _, err := net.SplitHostPort(strings.TrimSpace(c.Request.RemoteAddr))
@@ -212,8 +266,200 @@ func (c *Context) String(code int, format string, value ...any) {
 }
 
 // JSON 返回 json 类型响应体
-func (c *Context) JSON(code int, value ...any) {
-	c.Render(code, render.JSON{Data: value})
+func (c *Context) JSON(code int, obj any) {
+	c.Render(code, render.JSON{Data: obj})
+}
+
+// HTML 使用 Engine 加载的 HTML 模板渲染响应体，name 为模板名称
+func (c *Context) HTML(code int, name string, obj any) {
+	if c.engine == nil || c.engine.HTMLRender == nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, errors.New("没有加载 HTML 模板"))
+		return
+	}
+	instance := c.engine.HTMLRender.Instance(name, obj)
+	c.Render(code, instance)
+}
+
+// IndentedJSON 返回缩进格式的 json 类型响应体，便于阅读但会增加响应体大小，建议只在开发环境使用
+func (c *Context) IndentedJSON(code int, obj any) {
+	c.Render(code, render.IndentedJSON{Data: obj})
+}
+
+// SecureJSON 返回 json 类型响应体，数据为 json 数组时添加 Engine.SecureJSONPrefix 前缀，防止 json 劫持
+func (c *Context) SecureJSON(code int, obj any) {
+	c.Render(code, render.SecureJSON{Prefix: c.engine.secureJSONPrefix(), Data: obj})
+}
+
+// JSONP 使用查询参数 callback 指定的回调函数包装 json 数据，没有回调函数时返回 json 类型响应体
+func (c *Context) JSONP(code int, obj any) {
+	callback := c.Request.URL.Query().Get("callback")
+	if render.SanitizeCallback(callback) == "" {
+		c.Render(code, render.JSON{Data: obj})
+		return
+	}
+	c.Render(code, render.JsonpJSON{Callback: callback, Data: obj})
+}
+
+// AsciiJSON 返回非 ASCII 字符转义为 \uXXXX 的 json 类型响应体
+func (c *Context) AsciiJSON(code int, obj any) {
+	c.Render(code, render.AsciiJSON{Data: obj})
+}
+
+// PureJSON 返回不转义 HTML 字符的 json 类型响应体
+func (c *Context) PureJSON(code int, obj any) {
+	c.Render(code, render.PureJSON{Data: obj})
+}
+
+// XML 返回 xml 类型响应体，支持 H 等键为字符串的 map
+func (c *Context) XML(code int, obj any) {
+	c.Render(code, render.XML{Data: obj})
+}
+
+// YAML 返回 yaml 类型响应体
+func (c *Context) YAML(code int, obj any) {
+	c.Render(code, render.YAML{Data: obj})
+}
+
+// TOML 返回 toml 类型响应体
+func (c *Context) TOML(code int, obj any) {
+	c.Render(code, render.TOML{Data: obj})
+}
+
+// ProtoBuf 返回 protobuf 类型响应体，obj 必须实现 proto.Message
+func (c *Context) ProtoBuf(code int, obj any) {
+	c.Render(code, render.ProtoBuf{Data: obj})
+}
+
+// MsgPack 返回 msgpack 类型响应体
+func (c *Context) MsgPack(code int, obj any) {
+	c.Render(code, render.MsgPack{Data: obj})
+}
+
+// AbortWithStatusJSON 停止响应并返回 json 类型响应体
+func (c *Context) AbortWithStatusJSON(code int, jsonObj any) {
+	c.Abort()
+	c.JSON(code, jsonObj)
+}
+
+// AbortWithError 停止响应并将错误推送到上下文
+func (c *Context) AbortWithError(code int, err error) *Error {
+	c.AbortWithStatus(code)
+	return c.Error(err)
+}
+
+// Negotiate 内容协商配置，Offered 为服务端可以提供的格式，各格式的数据为空时使用 Data
+type Negotiate struct {
+	Offered      []string
+	JSONData     any
+	XMLData      any
+	YAMLData     any
+	TOMLData     any
+	ProtoBufData any // 必须实现 proto.Message
+	MsgPackData  any
+	Data         any
+}
+
+// Negotiate 根据 Accept 请求头选择合适的格式返回响应体
+func (c *Context) Negotiate(code int, config Negotiate) {
+	switch c.NegotiateFormat(config.Offered...) {
+	case binding.MIMEJSON:
+		data := chooseData(config.JSONData, config.Data)
+		c.JSON(code, data)
+
+	case binding.MIMEXML, binding.MIMEXML2:
+		data := chooseData(config.XMLData, config.Data)
+		c.XML(code, data)
+
+	case binding.MIMEYAML, binding.MIMEYAML2:
+		data := chooseData(config.YAMLData, config.Data)
+		c.YAML(code, data)
+
+	case binding.MIMETOML:
+		data := chooseData(config.TOMLData, config.Data)
+		c.TOML(code, data)
+
+	case binding.MIMEPROTOBUF:
+		data := chooseData(config.ProtoBufData, config.Data)
+		c.ProtoBuf(code, data)
+
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		data := chooseData(config.MsgPackData, config.Data)
+		c.MsgPack(code, data)
+
+	default:
+		_ = c.AbortWithError(http.StatusNotAcceptable, errors.New("服务端不提供可接受的格式"))
+	}
+}
+
+// NegotiateFormat 从服务端可提供的格式中选择客户端可接受的格式
+func (c *Context) NegotiateFormat(offered ...string) string {
+	assert1(len(offered) > 0, "必须提供至少一种格式")
+
+	if c.Accepted == nil {
+		c.Accepted = parseAccept(c.requestHeader("Accept"))
+	}
+	if len(c.Accepted) == 0 {
+		return offered[0]
+	}
+	for _, accepted := range c.Accepted {
+		for _, offer := range offered {
+			// 根据 RFC 2616 和 RFC 2396，Accept 请求头中不可能出现非 ASCII 字符，所以可以按字节比较
+			i := 0
+			for ; i < len(accepted) && i < len(offer); i++ {
+				if accepted[i] == '*' || offer[i] == '*' {
+					return offer
+				}
+				if accepted[i] != offer[i] {
+					break
+				}
+			}
+			if i == len(accepted) {
+				return offer
+			}
+		}
+	}
+	return ""
+}
+
+// SetAccepted 手动设置可接受的格式
+func (c *Context) SetAccepted(formats ...string) {
+	c.Accepted = formats
+}
+
+// 选择内容协商数据，custom 为空时使用默认数据
+func chooseData(custom, wildcard any) any {
+	if custom != nil {
+		return custom
+	}
+	if wildcard != nil {
+		return wildcard
+	}
+	panic("协商数据不能为空")
+}
+
+// Problem 返回 RFC 9457 问题详情文档，上下文中的公开错误会作为 errors 扩展成员返回
+func (c *Context) Problem(code int, problem render.Problem) {
+	if problem.Status == 0 {
+		problem.Status = code
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(code)
+	}
+	if publicErrors := c.Errors.ByType(ErrorTypePublic); len(publicErrors) > 0 {
+		if _, ok := problem.Extensions["errors"]; !ok {
+			extensions := make(map[string]any, len(problem.Extensions)+1)
+			for k, v := range problem.Extensions {
+				extensions[k] = v
+			}
+			errs := make([]any, len(publicErrors))
+			for i, err := range publicErrors {
+				errs[i] = err.JSON()
+			}
+			extensions["errors"] = errs
+			problem.Extensions = extensions
+		}
+	}
+	c.Render(code, problem)
 }
 
 // ContentType 获取ContentType
@@ -221,27 +467,47 @@ func (c *Context) ContentType() string {
 	return filterFlags(c.requestHeader("Content-Type"))
 }
 
+// TranslateError 根据 Accept-Language 请求头将参数校验错误翻译为字段级别的错误信息，err 不是参数校验错误时返回 nil
+func (c *Context) TranslateError(err error) binding.FieldErrors {
+	langs := acceptLanguages(c.requestHeader("Accept-Language"))
+	return binding.Translator.TranslateWithValidator(c.bindingConfig().StructValidator(), err, langs...)
+}
+
 // ShouldBindWith 绑定请求数据到指定的结构体，可以绑定多种类型数据，允许多次调用，每次调用都会重新读取和解析请求体
 func (c *Context) ShouldBindWith(obj any, b binding.Binding) error {
-	return b.Bind(c.Request, obj)
+	return c.bindingConfig().Bind(c.Request, obj, b)
+}
+
+// 获取 Engine 的绑定配置，为空时使用 binding 包的包级变量
+func (c *Context) bindingConfig() *binding.Config {
+	if c.engine == nil {
+		return nil
+	}
+	return c.engine.bindingConfig
 }
 
 // ShouldBindBodyWith 绑定请求数据到指定的结构体，但是和 ShouldBindWith 不同，只会读取请求体一次，并且会将请求体的内容缓存起来，以便后续操作可以重复使用数据，如果在处理过程中多次使用请求体数据，使用这个方法更为高效
-func (c *Context) ShouldBindBodyWith(obj any, bb binding.BindingBody) (err error) {
-	var body []byte
+func (c *Context) ShouldBindBodyWith(obj any, bb binding.BindingBody) error {
+	body, err := c.bodyBytes()
+	if err != nil {
+		return err
+	}
+	return c.bindingConfig().BindBody(body, obj, bb)
+}
+
+// 读取请求体并缓存到 BodyBytesKey，已经缓存时直接返回缓存的请求体
+func (c *Context) bodyBytes() ([]byte, error) {
 	if cb, ok := c.Get(BodyBytesKey); ok {
 		if cbb, ok := cb.([]byte); ok {
-			body = cbb
+			return cbb, nil
 		}
 	}
-	if body == nil {
-		body, err = io.ReadAll(c.Request.Body)
-		if err != nil {
-			return err
-		}
-		c.Set(BodyBytesKey, body)
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
 	}
-	return bb.BindBody(body, obj)
+	c.Set(BodyBytesKey, body)
+	return body, nil
 }
 
 // ShouldBind 根据 Method 和 ContentType 判断使用哪种参数绑定方法
@@ -254,3 +520,130 @@ func (c *Context) ShouldBind(obj any) error {
 func (c *Context) ShouldBindJSON(obj any) error {
 	return c.ShouldBindWith(obj, binding.JSON)
 }
+
+// ShouldBindXML 绑定 xml 请求数据到结构体
+func (c *Context) ShouldBindXML(obj any) error {
+	return c.ShouldBindWith(obj, binding.XML)
+}
+
+// ShouldBindYAML 绑定 yaml 请求数据到结构体
+func (c *Context) ShouldBindYAML(obj any) error {
+	return c.ShouldBindWith(obj, binding.YAML)
+}
+
+// ShouldBindTOML 绑定 toml 请求数据到结构体
+func (c *Context) ShouldBindTOML(obj any) error {
+	return c.ShouldBindWith(obj, binding.TOML)
+}
+
+// ShouldBindProtoBuf 绑定 protobuf 请求数据到 proto.Message
+func (c *Context) ShouldBindProtoBuf(obj any) error {
+	return c.ShouldBindWith(obj, binding.ProtoBuf)
+}
+
+// ShouldBindMsgPack 绑定 msgpack 请求数据到结构体
+func (c *Context) ShouldBindMsgPack(obj any) error {
+	return c.ShouldBindWith(obj, binding.MsgPack)
+}
+
+// ShouldBindHeader 绑定请求头数据到结构体
+func (c *Context) ShouldBindHeader(obj any) error {
+	return c.ShouldBindWith(obj, binding.Header)
+}
+
+// ShouldBindAll 按 precedence 指定的优先级从路由参数（uri 标签）、查询参数（form 标签）、请求头（header 标签）和请求体中绑定数据到同一个结构体，
+// 全部数据绑定完成后统一执行一次参数校验，precedence 为空时使用绑定配置中的 Precedence
+func (c *Context) ShouldBindAll(obj any, precedence ...binding.Source) error {
+	return c.bindingConfig().BindAll(c.Request, c.uriParams(), obj, precedence...)
+}
+
+// 将路由参数转换为绑定需要的格式
+func (c *Context) uriParams() map[string][]string {
+	m := make(map[string][]string, len(c.Params))
+	for _, v := range c.Params {
+		m[v.Key] = []string{v.Value}
+	}
+	return m
+}
+
+// ShouldBindQuery 绑定查询参数到结构体
+func (c *Context) ShouldBindQuery(obj any) error {
+	return c.ShouldBindWith(obj, binding.Query)
+}
+
+// ShouldBindUri 绑定路由参数到结构体，使用 uri 标签
+func (c *Context) ShouldBindUri(obj any) error {
+	return c.bindingConfig().BindUri(c.uriParams(), obj, binding.Uri)
+}
+
+// MustBindWith 绑定请求数据到指定的结构体，绑定失败时停止响应并交由 Engine.BindErrorHandler 写入响应，
+// 默认返回 400（不支持的媒体类型返回 415）并列出校验失败的字段
+func (c *Context) MustBindWith(obj any, b binding.Binding) error {
+	if err := c.ShouldBindWith(obj, b); err != nil {
+		c.abortWithBindError(err)
+		return err
+	}
+	return nil
+}
+
+// 将绑定错误推送到上下文并停止响应，响应由 Engine.BindErrorHandler 写入
+func (c *Context) abortWithBindError(err error) {
+	_ = c.Error(err).SetType(ErrorTypeBind)
+	c.Abort()
+	c.engine.bindErrorHandler()(c, err)
+}
+
+// 获取绑定错误对应的响应状态码，使用和错误处理器相同的内置错误映射 defaultErrorMappers，
+// 不支持的媒体类型返回 415，请求体超过长度限制返回 413，补丁 test 操作失败返回 409，其他错误返回 400
+func bindErrorStatus(err error) int {
+	for _, mapper := range defaultErrorMappers {
+		if code, ok := mapper(err); ok {
+			return code
+		}
+	}
+	return http.StatusBadRequest
+}
+
+// 组装绑定错误的响应体，参数校验错误会根据 Accept-Language 请求头列出翻译后的字段错误信息
+func bindErrorBody(c *Context, err error) H {
+	body := H{"error": err.Error()}
+	if fields := c.TranslateError(err); fields != nil {
+		body["fields"] = fields
+	}
+	return body
+}
+
+// Bind 根据请求方法和 Content-Type 选择绑定器绑定请求数据，绑定失败时停止响应并返回 400
+func (c *Context) Bind(obj any) error {
+	b := binding.Default(c.Request.Method, c.ContentType())
+	return c.MustBindWith(obj, b)
+}
+
+// BindJSON 绑定 json 请求数据到结构体，绑定失败时停止响应并返回 400
+func (c *Context) BindJSON(obj any) error {
+	return c.MustBindWith(obj, binding.JSON)
+}
+
+// BindQuery 绑定查询参数到结构体，绑定失败时停止响应并返回 400
+func (c *Context) BindQuery(obj any) error {
+	return c.MustBindWith(obj, binding.Query)
+}
+
+// BindUri 绑定路由参数到结构体，绑定失败时停止响应并返回 400
+func (c *Context) BindUri(obj any) error {
+	if err := c.ShouldBindUri(obj); err != nil {
+		c.abortWithBindError(err)
+		return err
+	}
+	return nil
+}
+
+// BindHeader 绑定请求头到结构体，绑定失败时停止响应并返回 400
+func (c *Context) BindHeader(obj any) error {
+	return c.MustBindWith(obj, binding.Header)
+}
+
+// BindXML 绑定 xml 请求数据到结构体，绑定失败时停止响应并返回 400
+func (c *Context) BindXML(obj any) error {
+	return c.MustBindWith(obj, binding.XML)
+}
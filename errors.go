@@ -2,7 +2,10 @@ package gin
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
+
+	"github.com/zhangweijie11/zGin/internal/json"
 )
 
 const (
@@ -11,7 +14,7 @@ const (
 	ErrorTypePrivate ErrorType = 1 << 0
 	ErrorTypePublic  ErrorType = 1 << 1
 	ErrorTypeAny     ErrorType = 1<<64 - 1
-	ErrorTypeNu                = 2
+	ErrorTypeNu      ErrorType = 1 << 2 // 其他类型错误，不属于公开错误
 )
 
 type ErrorType uint64
@@ -24,6 +27,68 @@ type Error struct {
 
 type errorMsgs []*Error
 
+var _ error = (*Error)(nil)
+
+// SetType 设置错误类型
+func (msg *Error) SetType(flags ErrorType) *Error {
+	msg.Type = flags
+	return msg
+}
+
+// SetMeta 设置错误原始数据
+func (msg *Error) SetMeta(data any) *Error {
+	msg.Meta = data
+	return msg
+}
+
+// JSON 组装可以序列化的错误数据，Meta 为 map 或结构体时会合并到结果中，其他类型的 Meta 放到 meta 字段中
+func (msg *Error) JSON() any {
+	jsonData := H{}
+	if msg.Meta != nil {
+		value := reflect.ValueOf(msg.Meta)
+		if value.Kind() == reflect.Ptr {
+			value = value.Elem()
+		}
+		switch value.Kind() {
+		case reflect.Struct:
+			// 结构体按照 json 标签转换为 map 后合并
+			var fields map[string]any
+			if data, err := json.Marshal(msg.Meta); err == nil && json.Unmarshal(data, &fields) == nil {
+				for k, v := range fields {
+					jsonData[k] = v
+				}
+			} else {
+				jsonData["meta"] = msg.Meta
+			}
+		case reflect.Map:
+			for _, key := range value.MapKeys() {
+				jsonData[fmt.Sprint(key.Interface())] = value.MapIndex(key).Interface()
+			}
+		default:
+			jsonData["meta"] = msg.Meta
+		}
+	}
+	if _, ok := jsonData["error"]; !ok {
+		jsonData["error"] = msg.Error()
+	}
+	return jsonData
+}
+
+// MarshalJSON 实现 json.Marshaller 接口
+func (msg *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(msg.JSON())
+}
+
+// Error 实现 error 接口
+func (msg *Error) Error() string {
+	return msg.Err.Error()
+}
+
+// Unwrap 返回真实错误
+func (msg *Error) Unwrap() error {
+	return msg.Err
+}
+
 // IsType 判断错误类型
 func (msg *Error) IsType(flags ErrorType) bool {
 	return (msg.Type & flags) > 0
@@ -46,6 +111,47 @@ func (a errorMsgs) ByType(typ ErrorType) errorMsgs {
 	return result
 }
 
+// Last 返回最后一个错误，没有错误时返回 nil
+func (a errorMsgs) Last() *Error {
+	if length := len(a); length > 0 {
+		return a[length-1]
+	}
+	return nil
+}
+
+// Errors 返回全部错误信息
+func (a errorMsgs) Errors() []string {
+	if len(a) == 0 {
+		return nil
+	}
+	errorStrings := make([]string, len(a))
+	for i, err := range a {
+		errorStrings[i] = err.Error()
+	}
+	return errorStrings
+}
+
+// JSON 组装可以序列化的错误数据，只有一个错误时直接返回该错误的数据
+func (a errorMsgs) JSON() any {
+	switch length := len(a); length {
+	case 0:
+		return nil
+	case 1:
+		return a.Last().JSON()
+	default:
+		jsonData := make([]any, length)
+		for i, err := range a {
+			jsonData[i] = err.JSON()
+		}
+		return jsonData
+	}
+}
+
+// MarshalJSON 实现 json.Marshaller 接口
+func (a errorMsgs) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.JSON())
+}
+
 // 组装错误信息
 func (a errorMsgs) String() string {
 	if len(a) == 0 {
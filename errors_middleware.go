@@ -0,0 +1,120 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/zhangweijie11/zGin/binding"
+	"github.com/zhangweijie11/zGin/render"
+)
+
+// ErrorMapper 将错误映射为响应状态码，ok 为 false 时表示无法映射
+type ErrorMapper func(err error) (code int, ok bool)
+
+// ErrorsConfig 错误处理器配置
+type ErrorsConfig struct {
+	Output      io.Writer         // 私有错误的日志输出路径，默认为 DefaultErrorWriter
+	StatusCodes map[ErrorType]int // 错误类型对应的响应状态码，未设置的错误类型使用默认状态码
+	Mappers     []ErrorMapper     // 自定义错误映射，优先于错误类型，按注册顺序匹配
+}
+
+// 错误类型默认的响应状态码，按严重程度从高到低排列
+var defaultErrorTypeCodes = []struct {
+	typ  ErrorType
+	code int
+}{
+	{ErrorTypePrivate, http.StatusInternalServerError},
+	{ErrorTypeRender, http.StatusInternalServerError},
+	{ErrorTypeBind, http.StatusBadRequest},
+	{ErrorTypePublic, http.StatusBadRequest},
+}
+
+// 内置的错误映射，在自定义错误映射之后匹配，Bind* 和 Typed 的绑定错误也使用这些映射获取响应状态码
+var defaultErrorMappers = []ErrorMapper{
+	MapErrorIs(binding.ErrUnsupportedMediaType, http.StatusUnsupportedMediaType),
+	MapErrorAs[*http.MaxBytesError](http.StatusRequestEntityTooLarge),
+	MapErrorIs(binding.ErrPatchTestFailed, http.StatusConflict),
+}
+
+// MapErrorIs 使用 errors.Is 将错误映射为响应状态码
+func MapErrorIs(target error, code int) ErrorMapper {
+	return func(err error) (int, bool) {
+		return code, errors.Is(err, target)
+	}
+}
+
+// MapErrorAs 使用 errors.As 将错误类型映射为响应状态码
+func MapErrorAs[T error](code int) ErrorMapper {
+	return func(err error) (int, bool) {
+		var target T
+		return code, errors.As(err, &target)
+	}
+}
+
+// ErrorsMiddleware 实例化错误处理器，处理器链路执行完成后，如果上下文中存在错误且尚未写入响应，
+// 则根据最严重的错误返回响应，响应体中只包含公开错误的信息，私有错误写入日志
+func ErrorsMiddleware(conf ErrorsConfig) HandlerFunc {
+	out := conf.Output
+	if out == nil {
+		out = DefaultErrorWriter
+	}
+
+	return func(c *Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		if privateErrors := c.Errors.ByType(ErrorTypePrivate); len(privateErrors) > 0 {
+			fmt.Fprintf(out, "[zGIN-error] %v | %s %s\n%s",
+				timeFormat(time.Now()), c.Request.Method, c.Request.URL.Path, privateErrors.String())
+		}
+
+		if c.Writer.Written() {
+			return
+		}
+
+		code := 0
+		for _, err := range c.Errors {
+			if errCode := conf.statusCode(err); errCode > code {
+				code = errCode
+			}
+		}
+
+		c.Abort()
+		if c.engine != nil && c.engine.UseProblemDetails {
+			c.Problem(code, render.Problem{})
+			return
+		}
+		body := H{"error": http.StatusText(code)}
+		if publicErrors := c.Errors.ByType(ErrorTypePublic); len(publicErrors) > 0 {
+			body["errors"] = publicErrors.Errors()
+		}
+		c.JSON(code, body)
+	}
+}
+
+// 获取错误对应的响应状态码，优先使用自定义错误映射，其次使用错误类型对应的状态码
+func (conf ErrorsConfig) statusCode(err *Error) int {
+	for _, mappers := range [][]ErrorMapper{conf.Mappers, defaultErrorMappers} {
+		for _, mapper := range mappers {
+			if code, ok := mapper(err.Err); ok {
+				return code
+			}
+		}
+	}
+	for _, tc := range defaultErrorTypeCodes {
+		if !err.IsType(tc.typ) {
+			continue
+		}
+		if code, ok := conf.StatusCodes[tc.typ]; ok {
+			return code
+		}
+		return tc.code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,31 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/zhangweijie11/zGin/binding"
+)
+
+func TestBindErrorStatusMatchesErrorsMiddleware(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("bind: %w", binding.ErrUnsupportedMediaType), http.StatusUnsupportedMediaType},
+		{fmt.Errorf("bind: %w", &http.MaxBytesError{Limit: 1}), http.StatusRequestEntityTooLarge},
+		{fmt.Errorf("bind: %w", binding.ErrPatchTestFailed), http.StatusConflict},
+		{errors.New("bind"), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if got := bindErrorStatus(tt.err); got != tt.want {
+			t.Fatalf("%v: got bind status %d, want %d", tt.err, got, tt.want)
+		}
+		got := ErrorsConfig{}.statusCode(&Error{Err: tt.err, Type: ErrorTypeBind})
+		if got != tt.want {
+			t.Fatalf("%v: got errors middleware status %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
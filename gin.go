@@ -1,9 +1,14 @@
 package gin
 
 import (
+	"errors"
+	"github.com/zhangweijie11/zGin/binding"
 	"github.com/zhangweijie11/zGin/internal/bytesconv"
+	"github.com/zhangweijie11/zGin/render"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"html/template"
+	"io/fs"
 	"net"
 	"net/http"
 	"path"
@@ -16,6 +21,7 @@ const defaultMultipartMemory = 32 << 20 // 32 MB
 const escapedColon = "\\:"
 const colon = ":"
 const backslash = "\\"
+const defaultSecureJSONPrefix = "while(1);"
 
 var regSafePrefix = regexp.MustCompile("[^a-zA-Z0-9/-]+")
 var regRemoveRepeatedChar = regexp.MustCompile("/{2,}")
@@ -29,27 +35,44 @@ type HandlerFunc func(*Context)
 
 type HandlersChain []HandlerFunc
 
+// HandlerFuncWithError 返回错误的处理器，错误交由 Engine.ErrorHandler 统一处理
+type HandlerFuncWithError func(*Context) error
+
+// ErrorHandlerFunc 统一处理处理器返回的错误
+type ErrorHandlerFunc func(*Context, error)
+
 type Engine struct {
 	RouterGroup
-	pool                  sync.Pool
-	maxSections           uint16
-	maxParams             uint16        // 最大参数长度
-	allNoRoute            HandlersChain // 全部未知路由
-	allNoMethod           HandlersChain // 全部未知请求类型
-	noRoute               HandlersChain // 未知路由
-	noMethod              HandlersChain // 未知请求类型
-	TrustedPlatform       string        // 是否信任该平台设置的标头,如果设置了则信任
-	trustedCIDRs          []*net.IPNet  // 信任的 IP 列表
-	ForwardedByClientIP   bool          // 是否允许转发 IP
-	RemoteIPHeaders       []string      // 客户端的请求头
-	trees                 methodTrees   // 路由树，以请求方法作为key ，该请求方法下的路由树作为 value
-	UseH2C                bool          // 是否启用 h2c 支持
-	UseRawPath            bool          // 是否可以从URL.RawPath 中查找参数
-	UnescapePathValues    bool          // 是否转义 path
-	RemoveExtraSlash      bool          // 是否开启即使有额外的斜杠，也可以从 URL 解析参数
-	RedirectTrailingSlash bool          // 是否允许重定向
-	RedirectFixedPath     bool          // 尝试修复路径进行重定向
-	HandleMethodNotAllow  bool          // 是否允许当前请求使用其他方法
+	pool                        sync.Pool
+	maxSections                 uint16
+	maxParams                   uint16            // 最大参数长度
+	allNoRoute                  HandlersChain     // 全部未知路由
+	allNoMethod                 HandlersChain     // 全部未知请求类型
+	noRoute                     HandlersChain     // 未知路由
+	noMethod                    HandlersChain     // 未知请求类型
+	TrustedPlatform             string            // 是否信任该平台设置的标头,如果设置了则信任
+	trustedCIDRs                []*net.IPNet      // 信任的 IP 列表
+	ForwardedByClientIP         bool              // 是否允许转发 IP
+	RemoteIPHeaders             []string          // 客户端的请求头
+	trees                       methodTrees       // 路由树，以请求方法作为key ，该请求方法下的路由树作为 value
+	UseH2C                      bool              // 是否启用 h2c 支持
+	UseRawPath                  bool              // 是否可以从URL.RawPath 中查找参数
+	UnescapePathValues          bool              // 是否转义 path
+	RemoveExtraSlash            bool              // 是否开启即使有额外的斜杠，也可以从 URL 解析参数
+	RedirectTrailingSlash       bool              // 是否允许重定向
+	RedirectFixedPath           bool              // 尝试修复路径进行重定向
+	HandleMethodNotAllow        bool              // 是否允许当前请求使用其他方法
+	ErrorHandler                ErrorHandlerFunc  // 处理 HandlerFuncWithError 返回的错误，为空时使用默认错误处理器
+	BindErrorHandler            ErrorHandlerFunc  // 处理 Bind* 和 Typed 的绑定错误，为空时返回 400 并列出校验失败的字段
+	UseProblemDetails           bool              // 默认的 404、405 和 500 响应体是否使用 RFC 9457 问题详情文档
+	MaxBodySize                 int64             // 请求体允许的最大长度，为 0 时为 32 MB，小于 0 时不限制，路由组可以使用 MaxBodySize 中间件覆盖
+	MaxDecompressedBodySize     int64             // 请求体解压后允许的最大长度，小于等于 0 时为 32 MB
+	DisableRequestDecompression bool              // 是否禁用 gzip、deflate 压缩请求体的透明解压
+	SecureJSONPrefix            string            // SecureJSON 响应体的前缀，为空时使用 while(1);
+	HTMLRender                  render.HTMLRender // HTML 模板渲染器，由 LoadHTMLGlob 等方法设置
+	FuncMap                     template.FuncMap  // HTML 模板的函数
+	delims                      render.Delims     // HTML 模板的分隔符
+	bindingConfig               *binding.Config   // 绑定配置，为空时使用 binding 包的包级变量
 }
 
 type OptionFunc func(*Engine)
@@ -125,6 +148,147 @@ func (engine *Engine) With(opts ...OptionFunc) *Engine {
 	return engine
 }
 
+// WithBindingConfig 设置 Engine 的绑定配置
+func WithBindingConfig(cfg *binding.Config) OptionFunc {
+	return func(engine *Engine) {
+		engine.bindingConfig = cfg
+		if cfg != nil {
+			addTranslatorValidator(cfg.StructValidator())
+		}
+	}
+}
+
+// WithValidator 设置 Engine 的参数验证器，为空时不执行参数校验
+func WithValidator(v binding.StructValidator) OptionFunc {
+	return func(engine *Engine) {
+		if v == nil {
+			v = binding.NoValidator
+		}
+		engine.ensureBindingConfig().Validator = v
+		addTranslatorValidator(v)
+	}
+}
+
+// 在处理请求之前向参数验证器注册校验错误的翻译
+func addTranslatorValidator(v binding.StructValidator) {
+	if err := binding.Translator.AddValidator(v); err != nil {
+		debugPrint("[WARNING] 注册参数校验错误的翻译失败: %v", err)
+	}
+}
+
+// WithDecoderUseNumber json 解码时将数字解码为 json.Number
+func WithDecoderUseNumber() OptionFunc {
+	return func(engine *Engine) {
+		engine.ensureBindingConfig().DecoderUseNumber = true
+	}
+}
+
+// WithDecoderDisallowUnknownFields json 解码时不允许出现未知字段
+func WithDecoderDisallowUnknownFields() OptionFunc {
+	return func(engine *Engine) {
+		engine.ensureBindingConfig().DecoderDisallowUnknownFields = true
+	}
+}
+
+// WithMaxBodySize 设置请求体允许的最大长度，默认为 32 MB，小于 0 时不限制
+func WithMaxBodySize(n int64) OptionFunc {
+	return func(engine *Engine) {
+		engine.MaxBodySize = n
+	}
+}
+
+// WithMaxDecompressedBodySize 设置请求体解压后允许的最大长度
+func WithMaxDecompressedBodySize(n int64) OptionFunc {
+	return func(engine *Engine) {
+		engine.MaxDecompressedBodySize = n
+	}
+}
+
+// WithSecureJSONPrefix 设置 SecureJSON 响应体的前缀
+func WithSecureJSONPrefix(prefix string) OptionFunc {
+	return func(engine *Engine) {
+		engine.SecureJSONPrefix = prefix
+	}
+}
+
+// 获取 SecureJSON 响应体的前缀
+func (engine *Engine) secureJSONPrefix() string {
+	if engine == nil || engine.SecureJSONPrefix == "" {
+		return defaultSecureJSONPrefix
+	}
+	return engine.SecureJSONPrefix
+}
+
+// Delims 设置 HTML 模板的左右分隔符，需要在加载模板之前调用
+func (engine *Engine) Delims(left, right string) *Engine {
+	engine.delims = render.Delims{Left: left, Right: right}
+	return engine
+}
+
+// SetFuncMap 设置 HTML 模板的函数，需要在加载模板之前调用
+func (engine *Engine) SetFuncMap(funcMap template.FuncMap) {
+	engine.FuncMap = funcMap
+}
+
+// LoadHTMLGlob 加载匹配模式对应的 HTML 模板，调试模式下每次渲染时重新加载
+func (engine *Engine) LoadHTMLGlob(pattern string) {
+	if IsDebugging() {
+		debugPrint("加载 HTML 模板（调试模式下每次渲染时重新加载）: %s", pattern)
+		engine.HTMLRender = render.HTMLDebug{Glob: pattern, FuncMap: engine.FuncMap, Delims: engine.delims}
+		return
+	}
+
+	templ := template.Must(engine.newTemplate().ParseGlob(pattern))
+	engine.SetHTMLTemplate(templ)
+}
+
+// LoadHTMLFiles 加载 HTML 模板文件，调试模式下每次渲染时重新加载
+func (engine *Engine) LoadHTMLFiles(files ...string) {
+	if IsDebugging() {
+		debugPrint("加载 HTML 模板（调试模式下每次渲染时重新加载）: %v", files)
+		engine.HTMLRender = render.HTMLDebug{Files: files, FuncMap: engine.FuncMap, Delims: engine.delims}
+		return
+	}
+
+	templ := template.Must(engine.newTemplate().ParseFiles(files...))
+	engine.SetHTMLTemplate(templ)
+}
+
+// LoadHTMLFS 从文件系统（例如 embed.FS）中加载匹配模式对应的 HTML 模板，调试模式下每次渲染时重新加载
+func (engine *Engine) LoadHTMLFS(fsys fs.FS, patterns ...string) {
+	if IsDebugging() {
+		debugPrint("加载 HTML 模板（调试模式下每次渲染时重新加载）: %v", patterns)
+		engine.HTMLRender = render.HTMLDebug{FS: fsys, Patterns: patterns, FuncMap: engine.FuncMap, Delims: engine.delims}
+		return
+	}
+
+	templ := template.Must(engine.newTemplate().ParseFS(fsys, patterns...))
+	engine.SetHTMLTemplate(templ)
+}
+
+// SetHTMLTemplate 使用已解析的模板作为 HTML 模板
+func (engine *Engine) SetHTMLTemplate(templ *template.Template) {
+	engine.HTMLRender = render.HTMLProduction{Template: templ.Funcs(engine.FuncMap)}
+}
+
+// 使用 Engine 的分隔符和函数创建模板
+func (engine *Engine) newTemplate() *template.Template {
+	return template.New("").Delims(engine.delims.Left, engine.delims.Right).Funcs(engine.FuncMap)
+}
+
+// BindingConfig 获取 Engine 的绑定配置，为空时表示使用 binding 包的包级变量
+func (engine *Engine) BindingConfig() *binding.Config {
+	return engine.bindingConfig
+}
+
+// 获取绑定配置，没有绑定配置时使用包级变量的当前值实例化
+func (engine *Engine) ensureBindingConfig() *binding.Config {
+	if engine.bindingConfig == nil {
+		engine.bindingConfig = binding.NewConfig()
+	}
+	return engine.bindingConfig
+}
+
 func Default(opts ...OptionFunc) *Engine {
 	engine := New()
 	engine.Use(Logger(), Recovery())
@@ -259,7 +423,7 @@ func (engine *Engine) handleHTTPRequest(c *Context) {
 		root := t[i].root
 		value := root.getValue(rPath, c.params, c.skippedNodes, unescape)
 		if value.params != nil {
-			c.params = value.params
+			c.Params = *value.params
 		}
 
 		if value.handlers != nil {
@@ -283,23 +447,75 @@ func (engine *Engine) handleHTTPRequest(c *Context) {
 	if engine.HandleMethodNotAllow && len(t) > 0 {
 		allowed := make([]string, 0, len(t)-1)
 		for _, tree := range engine.trees {
-			if tree.method != httpMethod {
+			if tree.method == httpMethod {
 				continue
 			}
-			if value := tree.root.getValue(rPath, nil, c.skippedNodes, unescape); value.params != nil {
+			if value := tree.root.getValue(rPath, nil, c.skippedNodes, unescape); value.handlers != nil {
 				allowed = append(allowed, tree.method)
 			}
 		}
 		if len(allowed) > 0 {
-			c.handlers = engine.Handlers
+			c.handlers = engine.allNoMethod
 			c.writermem.Header().Set("Allow", strings.Join(allowed, ", "))
 			serverError(c, http.StatusMethodNotAllowed, default405Body)
+			return
 		}
 	}
 	c.handlers = engine.allNoRoute
 	serverError(c, http.StatusNotFound, default404Body)
 }
 
+// 默认错误处理器，如果错误实现了 HTTPStatus() int 方法则使用其返回的状态码，否则返回 500
+func defaultErrorHandler(c *Context, err error) {
+	code := http.StatusInternalServerError
+	var se interface{ HTTPStatus() int }
+	if errors.As(err, &se) {
+		code = se.HTTPStatus()
+	}
+	c.Abort()
+	if c.Writer.Written() {
+		return
+	}
+	if c.engine != nil && c.engine.UseProblemDetails {
+		c.Problem(code, render.Problem{})
+		return
+	}
+	c.String(code, http.StatusText(code))
+}
+
+// 获取错误处理器
+func (engine *Engine) errorHandler() ErrorHandlerFunc {
+	if engine == nil || engine.ErrorHandler == nil {
+		return defaultErrorHandler
+	}
+	return engine.ErrorHandler
+}
+
+// 默认的绑定错误处理器，响应未写入时返回 400（不支持的媒体类型返回 415）并列出校验失败的字段
+func defaultBindErrorHandler(c *Context, err error) {
+	if c.Writer.Written() {
+		return
+	}
+	code := bindErrorStatus(err)
+	if c.engine != nil && c.engine.UseProblemDetails {
+		p := render.Problem{Detail: err.Error()}
+		if fields := c.TranslateError(err); fields != nil {
+			p.Extensions = map[string]any{"fields": fields}
+		}
+		c.Problem(code, p)
+		return
+	}
+	c.JSON(code, bindErrorBody(c, err))
+}
+
+// 获取绑定错误处理器，没有设置时使用默认绑定错误处理器
+func (engine *Engine) bindErrorHandler() ErrorHandlerFunc {
+	if engine == nil || engine.BindErrorHandler == nil {
+		return defaultBindErrorHandler
+	}
+	return engine.BindErrorHandler
+}
+
 func serverError(c *Context, code int, defaultMessage []byte) {
 	c.writermem.status = code
 	c.Next()
@@ -307,6 +523,10 @@ func serverError(c *Context, code int, defaultMessage []byte) {
 		return
 	}
 	if c.writermem.Status() == code {
+		if c.engine.UseProblemDetails {
+			c.Problem(code, render.Problem{Detail: string(defaultMessage)})
+			return
+		}
 		c.writermem.Header()["Content-Type"] = mimePlain
 		_, err := c.Writer.Write(defaultMessage)
 		if err != nil {
@@ -326,6 +546,7 @@ func (engine *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	c.Request = req
 	// 重置请求上下文
 	c.reset()
+	engine.wrapRequestBody(c)
 
 	// 处理请求
 	engine.handleHTTPRequest(c)
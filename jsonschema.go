@@ -0,0 +1,26 @@
+package gin
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/zhangweijie11/zGin/binding"
+)
+
+// JSONSchema 使用 JSON Schema 校验请求体的中间件，请求体缓存到 BodyBytesKey，后续处理器可以继续绑定请求体，
+// 校验失败时交由 Engine.BindErrorHandler 处理，默认返回 400 并按 JSON Pointer 列出全部错误
+func JSONSchema(schema *binding.Schema) HandlerFunc {
+	return func(c *Context) {
+		body, err := c.bodyBytes()
+		if err == nil {
+			err = schema.Validate(body)
+		}
+		if err != nil {
+			c.abortWithBindError(err)
+			return
+		}
+		// 请求体已经被读取，重新设置请求体使 ShouldBindJSON 等方法可以继续读取
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		c.Next()
+	}
+}
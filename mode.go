@@ -54,14 +54,17 @@ func SetMode(value string) {
 	modeName.Store(value)
 }
 
+// DisableBindValidation 关闭默认的参数校验，只影响没有设置参数验证器的 Engine
 func DisableBindValidation() {
 	binding.Validator = nil
 }
 
+// EnableJsonDecoderUseNumber 默认 json 解码时将数字解码为 json.Number，只影响没有设置绑定配置的 Engine
 func EnableJsonDecoderUseNumber() {
 	binding.EnableDecoderUseNumber = true
 }
 
+// EnableJsonDecoderDisallowUnknownFields 默认 json 解码时不允许出现未知字段，只影响没有设置绑定配置的 Engine
 func EnableJsonDecoderDisallowUnknownFields() {
 	binding.EnableDecoderDisallowUnknownFields = true
 }
@@ -0,0 +1,21 @@
+package gin
+
+// ApplyMergePatch 读取请求体中的 JSON Merge Patch（RFC 7386）文档并应用到已加载的资源 obj，
+// 值为 null 的字段恢复为零值，应用后重新执行参数校验，校验失败时不修改 obj
+func ApplyMergePatch(c *Context, obj any) error {
+	patch, err := c.bodyBytes()
+	if err != nil {
+		return err
+	}
+	return c.bindingConfig().ApplyMergePatch(patch, obj)
+}
+
+// ApplyJSONPatch 读取请求体中的 JSON Patch（RFC 6902）文档并应用到已加载的资源 obj，
+// 应用后重新执行参数校验，任意操作失败或校验失败时不修改 obj
+func ApplyJSONPatch(c *Context, obj any) error {
+	patch, err := c.bodyBytes()
+	if err != nil {
+		return err
+	}
+	return c.bindingConfig().ApplyJSONPatch(patch, obj)
+}
@@ -13,6 +13,8 @@ import (
 	"runtime"
 	"strings"
 	"time"
+
+	"github.com/zhangweijie11/zGin/render"
 )
 
 var (
@@ -36,6 +38,11 @@ func RecoveryWithWriter(out io.Writer, recovery ...RecoveryFunc) HandlerFunc {
 }
 
 func defaultHandleRecovery(c *Context, _ any) {
+	if c.engine != nil && c.engine.UseProblemDetails {
+		c.Abort()
+		c.Problem(http.StatusInternalServerError, render.Problem{})
+		return
+	}
 	c.AbortWithStatus(http.StatusInternalServerError)
 }
 
@@ -0,0 +1,91 @@
+package render
+
+import (
+	"html/template"
+	"io/fs"
+	"net/http"
+)
+
+// Delims 模板的左右分隔符
+type Delims struct {
+	Left  string
+	Right string
+}
+
+// HTMLRender HTML 模板渲染器，根据模板名称和数据创建 Render
+type HTMLRender interface {
+	Instance(string, any) Render
+}
+
+// HTMLProduction 生产环境的 HTML 模板渲染器，模板只解析一次
+type HTMLProduction struct {
+	Template *template.Template
+}
+
+// HTMLDebug 调试环境的 HTML 模板渲染器，每次渲染时重新从磁盘或文件系统解析模板，修改模板后无需重启服务
+type HTMLDebug struct {
+	Files    []string // 模板文件列表
+	Glob     string   // 模板文件的匹配模式
+	FS       fs.FS    // 模板所在的文件系统，配合 Patterns 使用
+	Patterns []string // 文件系统中模板文件的匹配模式
+	Delims   Delims
+	FuncMap  template.FuncMap
+}
+
+// HTML HTML 模板数据，Name 为空时执行 Template 本身
+type HTML struct {
+	Template *template.Template
+	Name     string
+	Data     any
+}
+
+var htmlContentType = []string{"text/html; charset=utf-8"}
+
+// Instance (HTMLProduction) 使用已解析的模板创建 HTML
+func (r HTMLProduction) Instance(name string, data any) Render {
+	return HTML{
+		Template: r.Template,
+		Name:     name,
+		Data:     data,
+	}
+}
+
+// Instance (HTMLDebug) 重新解析模板后创建 HTML
+func (r HTMLDebug) Instance(name string, data any) Render {
+	return HTML{
+		Template: r.loadTemplate(),
+		Name:     name,
+		Data:     data,
+	}
+}
+
+// 根据文件列表、匹配模式或文件系统解析模板，解析失败时 panic
+func (r HTMLDebug) loadTemplate() *template.Template {
+	if r.FuncMap == nil {
+		r.FuncMap = template.FuncMap{}
+	}
+	tmpl := template.New("").Delims(r.Delims.Left, r.Delims.Right).Funcs(r.FuncMap)
+	switch {
+	case len(r.Files) > 0:
+		return template.Must(tmpl.ParseFiles(r.Files...))
+	case r.Glob != "":
+		return template.Must(tmpl.ParseGlob(r.Glob))
+	case r.FS != nil && len(r.Patterns) > 0:
+		return template.Must(tmpl.ParseFS(r.FS, r.Patterns...))
+	}
+	panic("HTML 模板渲染器需要设置 Files、Glob 或 FS 和 Patterns")
+}
+
+// Render (HTML) 写入模板渲染后的 HTML 数据
+func (r HTML) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	if r.Name == "" {
+		return r.Template.Execute(w, r.Data)
+	}
+	return r.Template.ExecuteTemplate(w, r.Name, r.Data)
+}
+
+// WriteContentType (HTML) 写入 html 类型的 Content-Type
+func (r HTML) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, htmlContentType)
+}
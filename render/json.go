@@ -1,8 +1,15 @@
 package render
 
 import (
-	"github.com/zhangweijie11/zGin/internal/json"
+	"bytes"
+	"fmt"
 	"net/http"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/zhangweijie11/zGin/internal/bytesconv"
+	"github.com/zhangweijie11/zGin/internal/json"
 )
 
 type JSON struct {
@@ -34,3 +41,162 @@ func (r JSON) WriteContentType(w http.ResponseWriter) {
 func (r JSON) Render(w http.ResponseWriter) error {
 	return WriteJSON(w, r.Data)
 }
+
+// IndentedJSON 缩进格式的 json 数据
+type IndentedJSON struct {
+	Data any
+}
+
+// SecureJSON 数据为 json 数组时在响应体前添加前缀，防止 json 劫持
+type SecureJSON struct {
+	Prefix string
+	Data   any
+}
+
+// JsonpJSON 使用回调函数包装的 json 数据，用于跨域请求
+type JsonpJSON struct {
+	Callback string
+	Data     any
+}
+
+// AsciiJSON 非 ASCII 字符转义为 \uXXXX 的 json 数据
+type AsciiJSON struct {
+	Data any
+}
+
+// PureJSON 不转义 HTML 字符的 json 数据
+type PureJSON struct {
+	Data any
+}
+
+// Render (IndentedJSON) 写入缩进格式的 json 数据
+func (r IndentedJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	jsonBytes, err := json.MarshalIndent(r.Data, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(jsonBytes)
+	return err
+}
+
+// WriteContentType (IndentedJSON) 写入 json 类型的 Content-Type
+func (r IndentedJSON) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, jsonContentType)
+}
+
+// Render (SecureJSON) 数据为 json 数组时先写入前缀再写入 json 数据
+func (r SecureJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	jsonBytes, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	if bytes.HasPrefix(jsonBytes, []byte("[")) && bytes.HasSuffix(jsonBytes, []byte("]")) {
+		if _, err = w.Write(bytesconv.StringToBytes(r.Prefix)); err != nil {
+			return err
+		}
+	}
+	_, err = w.Write(jsonBytes)
+	return err
+}
+
+// WriteContentType (SecureJSON) 写入 json 类型的 Content-Type
+func (r SecureJSON) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, jsonContentType)
+}
+
+// Render (JsonpJSON) 写入 callback(json) 格式的数据，回调函数名称只保留 JavaScript 标识符字符，
+// 回调函数名称为空时写入 json 数据
+func (r JsonpJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	jsonBytes, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+
+	callback := SanitizeCallback(r.Callback)
+	if callback == "" {
+		_, err = w.Write(jsonBytes)
+		return err
+	}
+
+	// 前置的注释用于防止 Rosetta Flash 等利用回调函数名称构造响应内容的攻击
+	var buf bytes.Buffer
+	buf.Grow(len(callback) + len(jsonBytes) + 8)
+	buf.WriteString("/**/")
+	buf.WriteString(callback)
+	buf.WriteByte('(')
+	buf.Write(jsonBytes)
+	buf.WriteString(");")
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// WriteContentType (JsonpJSON) 写入 javascript 类型的 Content-Type
+func (r JsonpJSON) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, jsonpContentType)
+}
+
+// JSONP 回调函数名称允许的最大长度
+const maxCallbackLength = 128
+
+// SanitizeCallback 过滤 JSONP 回调函数名称，只保留字母、数字、_、$、. 和 []，超过 128 个字符时返回空字符串
+func SanitizeCallback(callback string) string {
+	var b strings.Builder
+	for _, r := range callback {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '_', r == '$', r == '.', r == '[', r == ']':
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > maxCallbackLength {
+		return ""
+	}
+	return b.String()
+}
+
+// Render (AsciiJSON) 将非 ASCII 字符转义为 \uXXXX 后写入 json 数据
+func (r AsciiJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	jsonBytes, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(jsonBytes))
+	for _, r := range bytesconv.BytesToString(jsonBytes) {
+		if r < utf8.RuneSelf {
+			buf.WriteByte(byte(r))
+			continue
+		}
+		// 超出基本多文种平面的字符使用 UTF-16 代理对表示
+		if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+			fmt.Fprintf(&buf, "\\u%04x\\u%04x", r1, r2)
+			continue
+		}
+		fmt.Fprintf(&buf, "\\u%04x", r)
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// WriteContentType (AsciiJSON) 写入 json 类型的 Content-Type
+func (r AsciiJSON) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, jsonASCIIContentType)
+}
+
+// Render (PureJSON) 写入不转义 <、>、& 等 HTML 字符的 json 数据
+func (r PureJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(r.Data)
+}
+
+// WriteContentType (PureJSON) 写入 json 类型的 Content-Type
+func (r PureJSON) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, jsonContentType)
+}
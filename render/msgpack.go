@@ -0,0 +1,33 @@
+package render
+
+import (
+	"net/http"
+
+	"github.com/ugorji/go/codec"
+)
+
+// MsgPack msgpack 数据
+type MsgPack struct {
+	Data any
+}
+
+var msgpackContentType = []string{"application/msgpack"}
+
+// Render (MsgPack) 写入 msgpack 数据
+func (r MsgPack) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	var bytes []byte
+	h := new(codec.MsgpackHandle)
+	// 字符串使用 str 格式编码而不是 raw 格式
+	h.WriteExt = true
+	if err := codec.NewEncoderBytes(&bytes, h).Encode(r.Data); err != nil {
+		return err
+	}
+	_, err := w.Write(bytes)
+	return err
+}
+
+// WriteContentType (MsgPack) 写入 msgpack 类型的 Content-Type
+func (r MsgPack) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, msgpackContentType)
+}
@@ -0,0 +1,66 @@
+package render
+
+import (
+	"net/http"
+
+	"github.com/zhangweijie11/zGin/internal/json"
+)
+
+// Problem RFC 9457 问题详情文档
+type Problem struct {
+	Type       string         // 问题类型的 URI 引用，为空时表示 about:blank
+	Title      string         // 问题类型的简短描述
+	Status     int            // 响应状态码
+	Detail     string         // 本次问题的具体描述
+	Instance   string         // 本次问题的 URI 引用
+	Extensions map[string]any // 扩展成员，序列化时与标准成员处于同一层级
+}
+
+var problemContentType = []string{"application/problem+json; charset=utf-8"}
+
+// problemMembers RFC 9457 定义的标准成员，扩展成员不能重新定义这些成员
+var problemMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// MarshalJSON 将扩展成员和标准成员序列化到同一层级，扩展成员中与标准成员同名的成员会被忽略，
+// 即使对应的标准成员为空
+func (r Problem) MarshalJSON() ([]byte, error) {
+	data := make(map[string]any, len(r.Extensions)+5)
+	for k, v := range r.Extensions {
+		data[k] = v
+	}
+	for _, k := range problemMembers {
+		delete(data, k)
+	}
+	if r.Type != "" {
+		data["type"] = r.Type
+	}
+	if r.Title != "" {
+		data["title"] = r.Title
+	}
+	if r.Status != 0 {
+		data["status"] = r.Status
+	}
+	if r.Detail != "" {
+		data["detail"] = r.Detail
+	}
+	if r.Instance != "" {
+		data["instance"] = r.Instance
+	}
+	return json.Marshal(data)
+}
+
+// Render (Problem) 写入问题详情文档
+func (r Problem) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	jsonBytes, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(jsonBytes)
+	return err
+}
+
+// WriteContentType (Problem) 写入 application/problem+json
+func (r Problem) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, problemContentType)
+}
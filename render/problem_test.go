@@ -0,0 +1,33 @@
+package render
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestProblemExtensionsCannotRedefineMembers(t *testing.T) {
+	p := Problem{
+		Title: "Not Found",
+		Extensions: map[string]any{
+			"type":     "evil",
+			"title":    "evil",
+			"status":   999,
+			"detail":   "evil",
+			"instance": "evil",
+			"traceId":  "abc",
+		},
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"title": "Not Found", "traceId": "abc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %s", data)
+	}
+}
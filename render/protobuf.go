@@ -0,0 +1,38 @@
+package render
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoBuf protobuf 数据，数据必须实现 proto.Message
+type ProtoBuf struct {
+	Data any
+}
+
+var protobufContentType = []string{"application/x-protobuf"}
+
+// ErrProtoBufData protobuf 数据没有实现 proto.Message
+var ErrProtoBufData = errors.New("protobuf 数据必须实现 proto.Message")
+
+// Render (ProtoBuf) 写入 protobuf 数据
+func (r ProtoBuf) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	msg, ok := r.Data.(proto.Message)
+	if !ok {
+		return ErrProtoBufData
+	}
+	bytes, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bytes)
+	return err
+}
+
+// WriteContentType (ProtoBuf) 写入 protobuf 类型的 Content-Type
+func (r ProtoBuf) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, protobufContentType)
+}
@@ -10,21 +10,24 @@ type Render interface {
 
 var (
 	_ Render = (*JSON)(nil)
-	//_ Render     = (*IndentedJSON)(nil)
-	//_ Render     = (*SecureJSON)(nil)
-	//_ Render     = (*JsonpJSON)(nil)
-	//_ Render     = (*XML)(nil)
+	_ Render = (*IndentedJSON)(nil)
+	_ Render = (*SecureJSON)(nil)
+	_ Render = (*JsonpJSON)(nil)
+	_ Render = (*XML)(nil)
 	_ Render = (*String)(nil)
+	_ Render = (*Problem)(nil)
 	//_ Render     = (*Redirect)(nil)
 	//_ Render     = (*Data)(nil)
-	//_ Render     = (*HTML)(nil)
-	//_ HTMLRender = (*HTMLDebug)(nil)
-	//_ HTMLRender = (*HTMLProduction)(nil)
-	//_ Render     = (*YAML)(nil)
+	_ Render     = (*HTML)(nil)
+	_ HTMLRender = (*HTMLDebug)(nil)
+	_ HTMLRender = (*HTMLProduction)(nil)
+	_ Render     = (*YAML)(nil)
 	//_ Render     = (*Reader)(nil)
-	//_ Render     = (*AsciiJSON)(nil)
-	//_ Render     = (*ProtoBuf)(nil)
-	//_ Render     = (*TOML)(nil)
+	_ Render = (*AsciiJSON)(nil)
+	_ Render = (*PureJSON)(nil)
+	_ Render = (*ProtoBuf)(nil)
+	_ Render = (*MsgPack)(nil)
+	_ Render = (*TOML)(nil)
 )
 
 func writeContentType(w http.ResponseWriter, value []string) {
@@ -0,0 +1,39 @@
+package render
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// TOML toml 数据，数据必须是结构体或 map
+type TOML struct {
+	Data any
+}
+
+var tomlContentType = []string{"application/toml; charset=utf-8"}
+
+// ErrTOMLData toml 文档的根节点必须是表，不能编码结构体和 map 之外的数据
+var ErrTOMLData = errors.New("toml 数据必须是结构体或 map")
+
+// Render (TOML) 写入 toml 数据
+func (r TOML) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	v := reflect.Indirect(reflect.ValueOf(r.Data))
+	if v.Kind() != reflect.Struct && v.Kind() != reflect.Map {
+		return ErrTOMLData
+	}
+	tomlBytes, err := toml.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(tomlBytes)
+	return err
+}
+
+// WriteContentType (TOML) 写入 toml 类型的 Content-Type
+func (r TOML) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, tomlContentType)
+}
@@ -0,0 +1,167 @@
+package render
+
+import (
+	"encoding"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// XML xml 数据，键为字符串的 map（例如 gin.H）会被编码为 <map><key>value</key></map>，
+// 切片、数组和指针中的 map 同样会被编码，map 的键必须是有效的 xml 元素名称
+type XML struct {
+	Data any
+}
+
+// ErrXMLName map 的键不是有效的 xml 元素名称
+var ErrXMLName = errors.New("无效的 xml 元素名称")
+
+var xmlContentType = []string{"application/xml; charset=utf-8"}
+
+// Render (XML) 写入 xml 数据
+func (r XML) Render(w http.ResponseWriter) error {
+	xmlBytes, err := xml.Marshal(xmlValue(r.Data))
+	if err != nil {
+		return err
+	}
+	r.WriteContentType(w)
+	_, err = w.Write(xmlBytes)
+	return err
+}
+
+// WriteContentType (XML) 写入 xml 类型的 Content-Type
+func (r XML) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, xmlContentType)
+}
+
+// xmlMap 键为字符串的 map，encoding/xml 默认不支持编码 map
+type xmlMap struct {
+	value reflect.Value
+}
+
+// xmlSlice 切片或数组，元素中可能包含需要包装的 map
+type xmlSlice struct {
+	value reflect.Value
+}
+
+var (
+	xmlMarshalerType  = reflect.TypeOf((*xml.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// 键为字符串的 map 包装为 xmlMap，切片和数组包装为 xmlSlice，实现了 xml.Marshaler 或 encoding.TextMarshaler 的数据和其他数据原样返回
+func xmlValue(data any) any {
+	return xmlReflectValue(reflect.ValueOf(data))
+}
+
+func xmlReflectValue(v reflect.Value) any {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	if v.Type().Implements(xmlMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return v.Interface()
+	}
+	if v.CanAddr() {
+		pt := reflect.PointerTo(v.Type())
+		if pt.Implements(xmlMarshalerType) || pt.Implements(textMarshalerType) {
+			return v.Addr().Interface()
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		switch v.Elem().Kind() {
+		case reflect.Map, reflect.Slice, reflect.Array, reflect.Interface, reflect.Ptr:
+			return xmlReflectValue(v.Elem())
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			return xmlMap{value: v}
+		}
+	case reflect.Slice, reflect.Array:
+		// []byte 和 [N]byte 由 encoding/xml 编码为字符数据
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return xmlSlice{value: v}
+		}
+	}
+	return v.Interface()
+}
+
+// MarshalXML 按键排序后将 map 的每个键值对编码为一个元素，值为 map、切片或数组时递归编码
+func (m xmlMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if start.Name.Local == "" || start.Name.Local == "xmlMap" {
+		start.Name = xml.Name{Local: "map"}
+	}
+
+	keys := m.value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	for _, key := range keys {
+		if !isXMLName(key.String()) {
+			return fmt.Errorf("%w: %q", ErrXMLName, key.String())
+		}
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		elem := xml.StartElement{Name: xml.Name{Local: key.String()}}
+		if err := e.EncodeElement(xmlReflectValue(m.value.MapIndex(key)), elem); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// MarshalXML 和 encoding/xml 一样将每个元素编码为一个同名元素，顶层的切片按元素自身的类型命名
+func (s xmlSlice) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	topLevel := start.Name.Local == "" || start.Name.Local == "xmlSlice"
+	for i := 0; i < s.value.Len(); i++ {
+		elem := xmlReflectValue(s.value.Index(i))
+		var err error
+		if topLevel {
+			err = e.Encode(elem)
+		} else {
+			err = e.EncodeElement(elem, start)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isXMLName 判断是否是有效的 xml 元素名称，不允许使用冒号，避免生成未声明的命名空间前缀
+func isXMLName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !isXMLNameStartChar(r) && (i == 0 || !isXMLNameChar(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isXMLNameStartChar(r rune) bool {
+	return r == '_' || ('A' <= r && r <= 'Z') || ('a' <= r && r <= 'z') ||
+		(0xC0 <= r && r <= 0xD6) || (0xD8 <= r && r <= 0xF6) || (0xF8 <= r && r <= 0x2FF) ||
+		(0x370 <= r && r <= 0x37D) || (0x37F <= r && r <= 0x1FFF) || (0x200C <= r && r <= 0x200D) ||
+		(0x2070 <= r && r <= 0x218F) || (0x2C00 <= r && r <= 0x2FEF) || (0x3001 <= r && r <= 0xD7FF) ||
+		(0xF900 <= r && r <= 0xFDCF) || (0xFDF0 <= r && r <= 0xFFFD) || (0x10000 <= r && r <= 0xEFFFF)
+}
+
+func isXMLNameChar(r rune) bool {
+	return r == '-' || r == '.' || ('0' <= r && r <= '9') || r == 0xB7 ||
+		(0x300 <= r && r <= 0x36F) || (0x203F <= r && r <= 0x2040)
+}
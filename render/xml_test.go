@@ -0,0 +1,52 @@
+package render
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+type xmlUser struct {
+	ID int `xml:"id"`
+}
+
+func TestXMLRenderMapsInSlices(t *testing.T) {
+	data := map[string]any{
+		"users": []map[string]any{{"id": 1}, {"id": 2}},
+		"ids":   [2]int{3, 4},
+		"owner": &map[string]any{"name": "gin"},
+		"items": []any{xmlUser{ID: 5}, map[string]any{"id": 6}},
+	}
+	w := httptest.NewRecorder()
+	if err := (XML{Data: data}).Render(w); err != nil {
+		t.Fatal(err)
+	}
+	want := "<map><ids>3</ids><ids>4</ids><items><id>5</id></items><items><id>6</id></items>" +
+		"<owner><name>gin</name></owner><users><id>1</id></users><users><id>2</id></users></map>"
+	if got := w.Body.String(); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestXMLRenderTopLevelSlice(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := (XML{Data: []map[string]any{{"id": 1}}}).Render(w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.Body.String(), "<map><id>1</id></map>"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestXMLRenderInvalidName(t *testing.T) {
+	for _, key := range []string{"", "1id", "a b", "a<b", "ns:id"} {
+		w := httptest.NewRecorder()
+		err := (XML{Data: map[string]any{"ok": []map[string]any{{key: 1}}}}).Render(w)
+		if !errors.Is(err, ErrXMLName) {
+			t.Fatalf("key %q: got error %v", key, err)
+		}
+		if w.Body.Len() != 0 || w.Header().Get("Content-Type") != "" {
+			t.Fatalf("key %q: 编码失败时不应写入响应", key)
+		}
+	}
+}
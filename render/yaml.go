@@ -0,0 +1,30 @@
+package render
+
+import (
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML yaml 数据
+type YAML struct {
+	Data any
+}
+
+var yamlContentType = []string{"application/yaml; charset=utf-8"}
+
+// Render (YAML) 写入 yaml 数据
+func (r YAML) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	yamlBytes, err := yaml.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(yamlBytes)
+	return err
+}
+
+// WriteContentType (YAML) 写入 yaml 类型的 Content-Type
+func (r YAML) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, yamlContentType)
+}
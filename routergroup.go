@@ -21,6 +21,15 @@ func (group *RouterGroup) Use(middleware ...HandlerFunc) IRoutes {
 	return group.returnObj()
 }
 
+// Group 创建新的路由组，新路由组继承当前路由组的处理器和路径前缀
+func (group *RouterGroup) Group(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return &RouterGroup{
+		Handlers: group.combineHandlers(handlers),
+		basePath: group.calculateAbsolutePath(relativePath),
+		engine:   group.engine,
+	}
+}
+
 func (group *RouterGroup) returnObj() IRoutes {
 	if group.root {
 		return group.engine
@@ -0,0 +1,69 @@
+package gin
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/zhangweijie11/zGin/binding"
+	"google.golang.org/protobuf/proto"
+)
+
+// TypedHandlerFunc 强类型处理器，Req 为请求结构体，Resp 为响应数据
+type TypedHandlerFunc[Req, Resp any] func(*Context, Req) (Resp, error)
+
+// 强类型处理器可以返回的响应格式，第一个为默认格式
+var typedOffered = []string{
+	binding.MIMEJSON, binding.MIMEXML, binding.MIMEXML2, binding.MIMEYAML, binding.MIMEYAML2, binding.MIMETOML,
+	binding.MIMEMSGPACK, binding.MIMEMSGPACK2,
+}
+
+// Typed 将强类型处理器转换为 HandlerFunc
+//
+// 请求结构体通过 ShouldBindAll 从路由参数、请求头、查询参数和请求体中绑定数据，绑定完成后执行参数校验，
+// 绑定或校验失败时交由 Engine.BindErrorHandler 处理，默认返回 400（不支持的媒体类型返回 415）并列出校验失败的字段；处理器返回的错误交由 Engine.ErrorHandler 处理；
+// 响应数据根据 Accept 请求头进行内容协商后返回，响应数据为 nil（包括空指针、空切片和空 map）时返回 204
+func Typed[Req, Resp any](fn TypedHandlerFunc[Req, Resp]) HandlerFunc {
+	offered := typedOffered
+	// 响应数据实现了 proto.Message 时才可以返回 protobuf 格式
+	var zero Resp
+	if _, ok := any(zero).(proto.Message); ok {
+		offered = append(slices.Clone(typedOffered), binding.MIMEPROTOBUF)
+	}
+
+	return func(c *Context) {
+		var req Req
+		if err := c.ShouldBindAll(&req); err != nil {
+			c.abortWithBindError(err)
+			return
+		}
+
+		resp, err := fn(c, req)
+		if err != nil {
+			_ = c.Error(err)
+			c.engine.errorHandler()(c, err)
+			return
+		}
+
+		// 没有响应数据时返回 204
+		if isNilResponse(resp) {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		c.Negotiate(http.StatusOK, Negotiate{Offered: offered, Data: resp})
+	}
+}
+
+// 判断响应数据是否为空，接口为 nil 或者为空指针、空切片、空 map
+func isNilResponse(resp any) bool {
+	if resp == nil {
+		return true
+	}
+	v := reflect.ValueOf(resp)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,39 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type typedItem struct {
+	ID int `json:"id"`
+}
+
+func TestTypedNilResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler HandlerFunc
+		want    int
+	}{
+		{"nil pointer", Typed(func(*Context, struct{}) (*typedItem, error) { return nil, nil }), http.StatusNoContent},
+		{"nil slice", Typed(func(*Context, struct{}) ([]typedItem, error) { return nil, nil }), http.StatusNoContent},
+		{"nil map", Typed(func(*Context, struct{}) (map[string]int, error) { return nil, nil }), http.StatusNoContent},
+		{"nil interface", Typed(func(*Context, struct{}) (any, error) { return nil, nil }), http.StatusNoContent},
+		{"empty slice", Typed(func(*Context, struct{}) ([]typedItem, error) { return []typedItem{}, nil }), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New()
+			r.GET("/", tt.handler)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			if w.Code != tt.want {
+				t.Fatalf("got status %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusNoContent && w.Body.Len() != 0 {
+				t.Fatalf("got body %q", w.Body.String())
+			}
+		})
+	}
+}
@@ -5,8 +5,12 @@ import (
 	"path"
 	"reflect"
 	"runtime"
+	"strings"
 )
 
+// H 是 map[string]any 的快捷方式
+type H map[string]any
+
 func assert1(guard bool, text string) {
 	if !guard {
 		panic(text)
@@ -41,6 +45,16 @@ func nameOfFunction(f any) string {
 	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
 }
 
+// WrapE 将返回错误的处理器转换为 HandlerFunc，返回的错误会记录到 c.Errors 中并交由 Engine.ErrorHandler 处理
+func WrapE(h HandlerFuncWithError) HandlerFunc {
+	return func(c *Context) {
+		if err := h(c); err != nil {
+			_ = c.Error(err)
+			c.engine.errorHandler()(c, err)
+		}
+	}
+}
+
 // 解析服务启动地址
 func resolveAddress(addr []string) string {
 	switch len(addr) {
@@ -68,3 +82,32 @@ func filterFlags(content string) string {
 
 	return content
 }
+
+// 解析 Accept 请求头，返回其中的 MIME 类型列表
+func parseAccept(acceptHeader string) []string {
+	parts := strings.Split(acceptHeader, ",")
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if i := strings.IndexByte(part, ';'); i > 0 {
+			part = part[:i]
+		}
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// 解析 Accept-Language 请求头，返回 locales 格式的语言列表，例如 zh-CN 会转换为 zh_CN 和 zh
+func acceptLanguages(acceptLanguage string) []string {
+	tags := parseAccept(acceptLanguage)
+	langs := make([]string, 0, len(tags)*2)
+	for _, tag := range tags {
+		tag = strings.ReplaceAll(tag, "-", "_")
+		langs = append(langs, tag)
+		if base, _, found := strings.Cut(tag, "_"); found {
+			langs = append(langs, base)
+		}
+	}
+	return langs
+}